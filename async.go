@@ -0,0 +1,112 @@
+package form_validator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// AsyncValidatorFunc is a custom validation function for slow checks that need I/O, such
+// as "username already taken". The ctx is canceled when the validation's context is done
+// or the Config's AsyncTimeout is reached. It returns nil or an *Error like a `ValidatorFunc`
+//
+//	usernameFree := func(ctx context.Context, value interface{}, form *form_validator.Result) *form_validator.Error {
+//		if taken, _ := users.Exists(ctx, value.(string)); taken {
+//			return &form_validator.Error{Type: "ERROR_TAKEN", Message: "This username is taken"}
+//		}
+//		return nil
+//	}
+type AsyncValidatorFunc func(ctx context.Context, value interface{}, form *Result) *Error
+
+// validateAsync runs the async validators of every field that is so far valid. The
+// validators of a field run in order while independent fields run concurrently, up to
+// the Config's MaxConcurrency
+func validateAsync(ctx context.Context, c *Config) {
+	var pending []int
+	for i, f := range c.Fields {
+		if len(f.AsyncValidators) > 0 && f.Value != nil && f.Error.Type == "" {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	// Validators are given a snapshot of the form as validators that outlive
+	// their timeout may still be reading it after validateAsync returns
+	form := &Result{config: *c}
+	form.config.Fields = make([]Field, len(c.Fields))
+	copy(form.config.Fields, c.Fields)
+
+	limit := c.MaxConcurrency
+	if limit <= 0 {
+		limit = len(pending)
+	}
+	sem := make(chan struct{}, limit)
+	errs := make([][]Error, len(pending))
+	var wg sync.WaitGroup
+	for n, i := range pending {
+		wg.Add(1)
+		go func(n int, f *Field) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[n] = append(errs[n], *contextError(ctx.Err()))
+				return
+			}
+			for _, validator := range f.AsyncValidators {
+				err := runAsyncValidator(ctx, validator, f.Value, form, c.AsyncTimeout)
+				if err == nil {
+					continue
+				}
+				errs[n] = append(errs[n], *err)
+				// Stop once the context is done as the remaining validators would fail too
+				if f.BailOnFirstError || ctx.Err() != nil {
+					return
+				}
+			}
+		}(n, &form.config.Fields[i])
+	}
+	wg.Wait()
+
+	for n, i := range pending {
+		for _, err := range errs[n] {
+			addValidatorError(&c.Fields[i], err)
+		}
+	}
+}
+
+// runAsyncValidator runs a single validator, returning a timeout or canceled error as soon
+// as the context is done even if the validator doesn't return
+func runAsyncValidator(ctx context.Context, validator AsyncValidatorFunc, value interface{}, form *Result, timeout time.Duration) *Error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	done := make(chan *Error, 1)
+	go func() {
+		done <- validator(ctx, value, form)
+	}()
+	select {
+	case err := <-done:
+		// Validators that return early because the context is done have not passed
+		if err == nil && ctx.Err() != nil {
+			return contextError(ctx.Err())
+		}
+		return err
+	case <-ctx.Done():
+		return contextError(ctx.Err())
+	}
+}
+
+// contextError converts a context error to a timeout or canceled error
+func contextError(err error) *Error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Type: ERROR_TIMEOUT}
+	}
+	return &Error{Type: ERROR_CANCELED}
+}
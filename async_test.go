@@ -0,0 +1,179 @@
+package form_validator
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore is an in-memory store of taken usernames & coupon codes
+type fakeStore struct {
+	mu        sync.Mutex
+	taken     map[string]bool
+	delay     time.Duration
+	active    int32
+	maxActive int32
+	calls     [][2]time.Time
+}
+
+func (s *fakeStore) exists(ctx context.Context, key string) (bool, error) {
+	start := time.Now()
+	active := atomic.AddInt32(&s.active, 1)
+	defer atomic.AddInt32(&s.active, -1)
+	for {
+		max := atomic.LoadInt32(&s.maxActive)
+		if active <= max || atomic.CompareAndSwapInt32(&s.maxActive, max, active) {
+			break
+		}
+	}
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, [2]time.Time{start, time.Now()})
+	return s.taken[key], nil
+}
+
+// overlapped reports whether any two calls to the store ran at the same time
+func (s *fakeStore) overlapped() bool {
+	for i, a := range s.calls {
+		for _, b := range s.calls[i+1:] {
+			if a[0].Before(b[1]) && b[0].Before(a[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *fakeStore) notTaken(ctx context.Context, value interface{}, form *Result) *Error {
+	taken, err := s.exists(ctx, value.(string))
+	if err != nil {
+		return nil
+	}
+	if taken {
+		return &Error{Type: "ERROR_TAKEN", Message: "Already taken"}
+	}
+	return nil
+}
+
+func TestAsyncValidators(t *testing.T) {
+	store := &fakeStore{taken: map[string]bool{"joe": true}, delay: 50 * time.Millisecond}
+	c := &Config{
+		Fields: []Field{
+			{
+				Name:            "username",
+				Validate:        true,
+				Type:            "string",
+				AsyncValidators: []AsyncValidatorFunc{store.notTaken},
+			},
+			{
+				Name:            "coupon",
+				Validate:        true,
+				Type:            "string",
+				AsyncValidators: []AsyncValidatorFunc{store.notTaken},
+			},
+		},
+	}
+
+	data := url.Values{}
+	data.Set("username", "joe")
+	data.Set("coupon", "SUMMER")
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, ValidateFormContext(context.Background(), r, c))
+		assert.Equal(t, Error{Type: "ERROR_TAKEN", Message: "Already taken"}, GetFormError("username", c))
+		assert.Equal(t, Error{}, GetFormError("coupon", c))
+	})
+	// Independent fields are checked concurrently
+	assert.Len(t, store.calls, 2)
+	assert.True(t, store.overlapped())
+}
+
+func TestAsyncValidatorsConcurrencyLimit(t *testing.T) {
+	store := &fakeStore{delay: 20 * time.Millisecond}
+	c := &Config{MaxConcurrency: 2}
+	data := url.Values{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		c.Fields = append(c.Fields, Field{
+			Name:            name,
+			Validate:        true,
+			Type:            "string",
+			AsyncValidators: []AsyncValidatorFunc{store.notTaken},
+		})
+		data.Set(name, "free")
+	}
+	s := NewSchema(c)
+
+	start := time.Now()
+	res := s.ValidateContext(context.Background(), newFormRequest(data))
+	assert.True(t, res.Valid())
+	assert.LessOrEqual(t, store.maxActive, int32(2))
+	// At most 2 of the 6 validators run at once, so they take at least 3 delays
+	assert.GreaterOrEqual(t, time.Since(start), 3*store.delay)
+	assert.True(t, store.overlapped())
+}
+
+func TestAsyncValidatorsTimeout(t *testing.T) {
+	store := &fakeStore{delay: time.Second}
+	c := &Config{
+		AsyncTimeout: 10 * time.Millisecond,
+		Fields: []Field{
+			{
+				Name:            "username",
+				Validate:        true,
+				Type:            "string",
+				AsyncValidators: []AsyncValidatorFunc{store.notTaken},
+			},
+		},
+	}
+	ignoresContext := func(ctx context.Context, value interface{}, form *Result) *Error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	c.Fields = append(c.Fields, Field{
+		Name:            "coupon",
+		Type:            "string",
+		AsyncValidators: []AsyncValidatorFunc{ignoresContext},
+	})
+	s := NewSchema(c)
+
+	data := url.Values{}
+	data.Set("username", "joe")
+	data.Set("coupon", "SUMMER")
+	start := time.Now()
+	res := s.Validate(newFormRequest(data))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, Error{Type: ERROR_TIMEOUT, Message: "Validation of username field timed out"}, res.Error("username"))
+	assert.Equal(t, ERROR_TIMEOUT, res.Error("coupon").Type)
+}
+
+func TestAsyncValidatorsCanceled(t *testing.T) {
+	store := &fakeStore{delay: time.Second}
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:            "username",
+				Validate:        true,
+				Type:            "string",
+				AsyncValidators: []AsyncValidatorFunc{store.notTaken},
+			},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data := url.Values{}
+	data.Set("username", "joe")
+	res := s.ValidateContext(ctx, newFormRequest(data))
+	assert.False(t, res.Valid())
+	assert.Equal(t, Error{Type: ERROR_CANCELED, Message: "Validation of username field was canceled"}, res.Error("username"))
+}
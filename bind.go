@@ -0,0 +1,249 @@
+package form_validator
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// defaultMaxMemory matches the net/http default used by `Request.FormValue`
+const defaultMaxMemory = 32 << 20
+
+// bindTypes maps the reflect kinds that can be bound to the `Field.Type` used by `convertToType`
+var bindTypes = map[reflect.Kind]string{
+	reflect.String:  "string",
+	reflect.Bool:    "bool",
+	reflect.Float32: "float32",
+	reflect.Float64: "float64",
+	reflect.Int:     "int",
+	reflect.Int8:    "int8",
+	reflect.Int16:   "int16",
+	reflect.Int32:   "int32",
+	reflect.Int64:   "int64",
+	reflect.Uint:    "uint",
+	reflect.Uint8:   "uint8",
+	reflect.Uint16:  "uint16",
+	reflect.Uint32:  "uint32",
+	reflect.Uint64:  "uint64",
+}
+
+// bindFormats maps the struct field types of the semantic formats to their `Field.Type`
+var bindFormats = map[reflect.Type]string{
+	reflect.TypeOf(mail.Address{}):            "email",
+	reflect.TypeOf(&url.URL{}):                "url",
+	reflect.TypeOf(net.IP{}):                  "ip",
+	reflect.TypeOf(netip.Prefix{}):            "cidr",
+	reflect.TypeOf(&multipart.FileHeader{}):   "file",
+	reflect.TypeOf([]*multipart.FileHeader{}): "[]file",
+}
+
+// Bind validates the request's form values against the struct tags of `dst` & writes
+// the converted values into the struct's fields. `dst` must be a pointer to a struct.
+//
+//	type SignUp struct {
+//		Name            string `form:"name" default:"John"`
+//		Email           string `form:"email" validate:"required"`
+//		Password        string `form:"password" validate:"required"`
+//		ConfirmPassword string `form:"confirm_password" validate:"required" matches:"password"`
+//		Age             *uint8 `form:"age"`
+//	}
+//
+//	var s SignUp
+//	formErrs, err := form_validator.Bind(r, &s)
+//
+// The tags are as followed:
+//
+// - form is the form's 'name' value, fields without a form tag (or `form:"-"`) are skipped
+// - validate set to "required" sets `Field.Validate`
+// - default sets `Field.Default`
+// - matches sets `Field.Matches` to another field's form name
+// - type overrides the `Field.Type` that is otherwise taken from the struct field's type,
+// e.g. `type:"email"` on a string field
+//
+// Pointer fields are optional, they are left nil if the form value is empty.
+// Slice fields hold every value submitted for a multi value field e.g. a checkbox group,
+// except []byte fields which hold the submitted string.
+// The returned `FormErrors` is nil if the form is valid. An error is only returned
+// if `dst` can't be bound to, or wraps `ErrInvalidBody` if the request body was rejected.
+// The temporary files of a valid multipart form are removed by `net/http` once the handler
+// returns, use `BindResult` to remove them sooner.
+func Bind(r *http.Request, dst any) (FormErrors, error) {
+	res, err := BindResult(r, dst)
+	if err != nil {
+		return nil, err
+	}
+	if res.config.bodyErr != nil {
+		return nil, res.config.bodyErr
+	}
+	if res.Valid() {
+		return nil, nil
+	}
+	return res.Errors(), nil
+}
+
+// BindResult binds the request's form values to `dst` like `Bind` & returns the `Result`,
+// whose `Close` removes the temporary files of a valid multipart form
+//
+//	res, err := form_validator.BindResult(r, &s)
+//	if err != nil {
+//		return err
+//	}
+//	defer res.Close()
+func BindResult(r *http.Request, dst any) (*Result, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("form_validator: Bind requires a non nil pointer to a struct")
+	}
+	b, err := bindSchemaFor(rv.Elem().Type())
+	if err != nil {
+		return nil, err
+	}
+	res := b.schema.Validate(r)
+	for i, f := range res.config.Fields {
+		setStructField(rv.Elem().Field(b.indexes[i]), &f)
+	}
+	return res, nil
+}
+
+// bindSchema is the compiled `Schema` of a struct type & the struct field index
+// for each of the schema's fields
+type bindSchema struct {
+	schema  *Schema
+	indexes []int
+}
+
+// bindSchemas caches a *bindSchema per struct type so tags are only parsed once
+var bindSchemas sync.Map
+
+func bindSchemaFor(t reflect.Type) (*bindSchema, error) {
+	if b, ok := bindSchemas.Load(t); ok {
+		return b.(*bindSchema), nil
+	}
+	c, indexes, err := bindConfig(t)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := bindSchemas.LoadOrStore(t, &bindSchema{schema: NewSchema(c), indexes: indexes})
+	return b.(*bindSchema), nil
+}
+
+// bindConfig builds a `Config` from the struct's tags. The returned indexes hold the
+// struct field index for each `Config.Fields` entry.
+func bindConfig(t reflect.Type) (*Config, []int, error) {
+	c := &Config{MaxMemory: defaultMaxMemory, bind: true}
+	var indexes []int
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Tag.Get("form")
+		if name == "" || name == "-" || !sf.IsExported() {
+			continue
+		}
+		fieldType := sf.Tag.Get("type")
+		if fieldType == "" {
+			fieldType = bindFormats[sf.Type]
+		}
+		if fieldType == "" && sf.Type.Kind() == reflect.Slice && sf.Type.Elem().Kind() == reflect.Uint8 {
+			// []byte fields hold the submitted string rather than a group of uint8 values
+			fieldType = "string"
+		}
+		if fieldType == "" {
+			ft := sf.Type
+			prefix := ""
+			switch ft.Kind() {
+			case reflect.Pointer:
+				ft = ft.Elem()
+			case reflect.Slice:
+				// Slices bind multi value fields such as checkbox groups
+				ft = ft.Elem()
+				prefix = "[]"
+			}
+			var ok bool
+			if fieldType, ok = bindTypes[ft.Kind()]; !ok {
+				return nil, nil, fmt.Errorf("form_validator: unsupported type %s for field %s", sf.Type, sf.Name)
+			}
+			fieldType = prefix + fieldType
+		}
+		f := Field{
+			Name:    name,
+			Label:   sf.Tag.Get("label"),
+			Source:  sf.Tag.Get("source"),
+			Default: sf.Tag.Get("default"),
+			Type:    fieldType,
+			Matches: sf.Tag.Get("matches"),
+		}
+		for _, rule := range strings.Split(sf.Tag.Get("validate"), ",") {
+			if strings.TrimSpace(rule) == "required" {
+				f.Validate = true
+			}
+		}
+		c.Fields = append(c.Fields, f)
+		indexes = append(indexes, i)
+	}
+	return c, indexes, nil
+}
+
+// setStructField writes the field's converted value to the struct field. Values that
+// failed conversion or were never submitted leave the struct field untouched.
+func setStructField(sv reflect.Value, f *Field) {
+	if f.Value == nil || setValueToInitialOrDefault(f) == "" {
+		return
+	}
+	v := reflect.ValueOf(f.Value)
+	if v.Type().AssignableTo(sv.Type()) {
+		sv.Set(v)
+		return
+	}
+	if addr, ok := f.Value.(mail.Address); ok {
+		// Email addresses are bound to string fields without the angle brackets
+		v = reflect.ValueOf(addr.Address)
+	}
+	t := sv.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if !v.Type().AssignableTo(t) {
+		if v = convertTo(v, t); !v.IsValid() {
+			return
+		}
+	}
+	if sv.Kind() == reflect.Pointer {
+		p := reflect.New(t)
+		p.Elem().Set(v)
+		v = p
+	}
+	sv.Set(v)
+}
+
+// convertTo converts v to the struct field type t, slices are converted element by element
+// so a []uint64 value binds to a []ID field. It returns the zero Value if v can't be converted
+func convertTo(v reflect.Value, t reflect.Type) reflect.Value {
+	if v.Kind() == reflect.String && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return v.Convert(t)
+	}
+	if v.Kind() == reflect.Slice && t.Kind() == reflect.Slice {
+		s := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			if !item.Type().AssignableTo(t.Elem()) {
+				if item = convertTo(item, t.Elem()); !item.IsValid() {
+					return reflect.Value{}
+				}
+			}
+			s.Index(i).Set(item)
+		}
+		return s
+	}
+	// Named types such as `type Status string` share the kind of the converted value
+	if v.Kind() != t.Kind() || !v.Type().ConvertibleTo(t) {
+		return reflect.Value{}
+	}
+	return v.Convert(t)
+}
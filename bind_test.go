@@ -0,0 +1,187 @@
+package form_validator
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type signUpForm struct {
+	Name            string  `form:"name" default:"John"`
+	Email           string  `form:"email" validate:"required"`
+	Password        string  `form:"password" validate:"required"`
+	ConfirmPassword string  `form:"confirm_password" validate:"required" matches:"password"`
+	Age             uint8   `form:"age"`
+	Weight          float32 `form:"weight"`
+	Subscribe       bool    `form:"subscribe"`
+	Score           *int64  `form:"score"`
+	Nickname        *string `form:"nickname"`
+	Ignored         string
+}
+
+func TestBind(t *testing.T) {
+	data := url.Values{}
+	data.Set("email", "joe@email.com")
+	data.Set("password", "wizard")
+	data.Set("confirm_password", "wizard")
+	data.Set("age", "42")
+	data.Set("weight", "2.43")
+	data.Set("subscribe", "true")
+	data.Set("score", "-9000")
+	data.Set("Ignored", "nope")
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		var s signUpForm
+		formErrs, err := Bind(r, &s)
+		assert.NoError(t, err)
+		assert.Nil(t, formErrs)
+		assert.Equal(t, "John", s.Name)
+		assert.Equal(t, "joe@email.com", s.Email)
+		assert.Equal(t, "wizard", s.ConfirmPassword)
+		assert.Equal(t, uint8(42), s.Age)
+		assert.Equal(t, float32(2.43), s.Weight)
+		assert.True(t, s.Subscribe)
+		if assert.NotNil(t, s.Score) {
+			assert.Equal(t, int64(-9000), *s.Score)
+		}
+		assert.Nil(t, s.Nickname)
+		assert.Equal(t, "", s.Ignored)
+	})
+}
+
+func TestBindFormErrors(t *testing.T) {
+	data := url.Values{}
+	data.Set("password", "wizard")
+	data.Set("confirm_password", "blizzard")
+	data.Set("age", "old")
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		var s signUpForm
+		formErrs, err := Bind(r, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, "Missing value for email field", formErrs["email"]["error"])
+		assert.Equal(t, "Fields confirm_password and password should match.", formErrs["confirm_password"]["error"])
+		assert.Equal(t, "Expected a value of type uint8 for age field", formErrs["age"]["error"])
+		assert.Equal(t, uint8(0), s.Age)
+	})
+
+	// Values that overflow the type leave the struct field untouched
+	data.Set("age", "300")
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		s := signUpForm{Age: 42}
+		formErrs, err := Bind(r, &s)
+		assert.NoError(t, err)
+		assert.Contains(t, formErrs, "age")
+		assert.Equal(t, uint8(42), s.Age)
+	})
+}
+
+func TestBindInvalidDestination(t *testing.T) {
+	data := url.Values{}
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		var s signUpForm
+		_, err := Bind(r, s)
+		assert.Error(t, err)
+
+		var unsupported struct {
+			Tags map[string]string `form:"tags"`
+		}
+		_, err = Bind(r, &unsupported)
+		assert.Error(t, err)
+	})
+
+	// The fields of a rejected body aren't reported as missing
+	var s signUpForm
+	formErrs, err := Bind(newJSONRequest(`{"email": "joe@email.com"`), &s)
+	assert.ErrorIs(t, err, ErrInvalidBody)
+	assert.Nil(t, formErrs)
+}
+
+func TestBindFormats(t *testing.T) {
+	var s struct {
+		Email   string   `form:"email" type:"email" validate:"required"`
+		Website *url.URL `form:"website"`
+		IP      net.IP   `form:"ip"`
+	}
+	data := url.Values{}
+	data.Set("email", "joe@email.com")
+	data.Set("website", "https://example.com")
+	data.Set("ip", "127.0.0.1")
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		formErrs, err := Bind(r, &s)
+		assert.NoError(t, err)
+		assert.Nil(t, formErrs)
+		assert.Equal(t, "joe@email.com", s.Email)
+		assert.Equal(t, "example.com", s.Website.Host)
+		assert.True(t, s.IP.Equal(net.ParseIP("127.0.0.1")))
+	})
+}
+
+func TestBindMultiValues(t *testing.T) {
+	var s struct {
+		Tags []string `form:"tags" validate:"required"`
+		IDs  []uint64 `form:"ids"`
+	}
+	data := url.Values{}
+	data.Add("tags", "go")
+	data.Add("tags", "forms")
+	data.Add("ids", "1")
+	data.Add("ids", "18446744073709551615")
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		formErrs, err := Bind(r, &s)
+		assert.NoError(t, err)
+		assert.Nil(t, formErrs)
+		assert.Equal(t, []string{"go", "forms"}, s.Tags)
+		assert.Equal(t, []uint64{1, 18446744073709551615}, s.IDs)
+	})
+}
+
+type bindID uint64
+
+type bindStatus string
+
+type bindKey []byte
+
+func TestBindNamedSliceTypes(t *testing.T) {
+	var s struct {
+		IDs      []bindID     `form:"ids"`
+		Statuses []bindStatus `form:"statuses"`
+		Token    []byte       `form:"token"`
+		Key      bindKey      `form:"key"`
+	}
+	data := url.Values{}
+	data.Add("ids", "1")
+	data.Add("ids", "2")
+	data.Add("statuses", "open")
+	data.Set("token", "hello")
+	data.Set("key", "secret")
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		formErrs, err := Bind(r, &s)
+		assert.NoError(t, err)
+		assert.Nil(t, formErrs)
+		assert.Equal(t, []bindID{1, 2}, s.IDs)
+		assert.Equal(t, []bindStatus{"open"}, s.Statuses)
+		// []byte fields hold the submitted string rather than a group of uint8 values
+		assert.Equal(t, []byte("hello"), s.Token)
+		assert.Equal(t, bindKey("secret"), s.Key)
+	})
+}
+
+func TestBindLabels(t *testing.T) {
+	var s struct {
+		Email string `form:"email" label:"Email address" validate:"required"`
+	}
+	createFormRequest(url.Values{}, func(w http.ResponseWriter, r *http.Request) {
+		formErrs, err := Bind(r, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, "Missing value for Email address field", formErrs["email"]["error"])
+		assert.Equal(t, "Email address", formErrs["email"]["label"])
+	})
+}
@@ -0,0 +1,138 @@
+package form_validator
+
+// bundledCatalogs are the catalogs returned by `NewCatalogs`. The English catalog
+// holds the built-in messages so it can be used as a starting point for other locales
+var bundledCatalogs = map[string]Catalog{
+	"en": {
+		ERROR_MISSING_VALUE:       "Missing value for {{.Label}} field",
+		ERROR_INCORRECT_TYPE:      "Expected a value of type {{.Type}} for {{.Label}} field",
+		ERROR_FIELDS_DO_NOT_MATCH: "Fields {{.Label}} and {{.MatchesLabel}} should match.",
+		ERROR_TOO_SHORT:           "{{.Label}} field must be {{if .Length}}exactly {{.Length}}{{else}}at least {{.Min}}{{end}} characters",
+		ERROR_TOO_LONG:            "{{.Label}} field must be {{if .Length}}exactly {{.Length}}{{else}}at most {{.Max}}{{end}} characters",
+		ERROR_OUT_OF_RANGE: "{{.Label}} field must be " +
+			"{{if .Date}}" +
+			"{{if and .Min .Max}}between {{.Min}} and {{.Max}}{{else if .Min}}on or after {{.Min}}{{else}}on or before {{.Max}}{{end}}" +
+			"{{else}}" +
+			"{{if .Min}}{{if .ExclusiveMin}}greater than{{else}}at least{{end}} {{.Min}}{{end}}" +
+			"{{if and .Min .Max}} and {{end}}" +
+			"{{if .Max}}{{if .ExclusiveMax}}less than{{else}}at most{{end}} {{.Max}}{{end}}" +
+			"{{end}}",
+		ERROR_PATTERN_MISMATCH: "{{.Label}} field {{if .Hint}}{{.Hint}}{{else}}is not in the correct format{{end}}",
+		ERROR_INVALID_CHOICE:   "{{.Label}} field must be one of {{.Choices}}",
+		ERROR_TOO_FEW_ITEMS:    "{{.Label}} field must have at least {{.Min}} values",
+		ERROR_TOO_MANY_ITEMS:   "{{.Label}} field must have at most {{.Max}} values",
+		ERROR_CUSTOM:           "Invalid value for {{.Label}} field",
+		ERROR_TIMEOUT:          "Validation of {{.Label}} field timed out",
+		ERROR_CANCELED:         "Validation of {{.Label}} field was canceled",
+		ERROR_FILE_TYPE:        "{{.Label}} file could not be read",
+		ERROR_FILE_TOO_LARGE:   "{{.Label}} file must be at most {{.Max}}",
+		ERROR_FILE_EXTENSION:   "{{.Label}} file must have one of the extensions {{.Choices}}",
+		ERROR_FILE_MIME_TYPE:   "{{.Label}} file must be of type {{.Choices}}",
+		ERROR_IMAGE_TOO_SMALL:  "{{.Label}} image must be at least {{if .Width}}{{.Width}} pixels wide{{end}}{{if and .Width .Height}} and {{end}}{{if .Height}}{{.Height}} pixels high{{end}}",
+		ERROR_IMAGE_TOO_LARGE:  "{{.Label}} image must be at most {{if .Width}}{{.Width}} pixels wide{{end}}{{if and .Width .Height}} and {{end}}{{if .Height}}{{.Height}} pixels high{{end}}",
+		ERROR_ASPECT_RATIO:     "{{.Label}} image must have an aspect ratio of {{.Ratio}}",
+		ERROR_TOO_MANY_PIXELS:  "{{.Label}} image must have at most {{.Max}} pixels",
+		ERROR_TOO_FEW_FILES:    "{{.Label}} field must have at least {{.Min}} files",
+		ERROR_TOO_MANY_FILES:   "{{.Label}} field must have at most {{.Max}} files",
+		ERROR_FILES_TOO_LARGE:  "{{.Label}} files must be at most {{.Max}} in total",
+	},
+	"de": {
+		ERROR_MISSING_VALUE:       "Das Feld {{.Label}} ist ein Pflichtfeld",
+		ERROR_INCORRECT_TYPE:      "Das Feld {{.Label}} erwartet einen Wert vom Typ {{.Type}}",
+		ERROR_FIELDS_DO_NOT_MATCH: "Die Felder {{.Label}} und {{.MatchesLabel}} müssen übereinstimmen.",
+		ERROR_TOO_SHORT:           "Das Feld {{.Label}} muss {{if .Length}}genau {{.Length}}{{else}}mindestens {{.Min}}{{end}} Zeichen lang sein",
+		ERROR_TOO_LONG:            "Das Feld {{.Label}} darf {{if .Length}}nur genau {{.Length}}{{else}}höchstens {{.Max}}{{end}} Zeichen lang sein",
+		ERROR_OUT_OF_RANGE: "Das Feld {{.Label}} muss " +
+			"{{if .Date}}" +
+			"{{if and .Min .Max}}zwischen {{.Min}} und {{.Max}} liegen{{else if .Min}}am oder nach dem {{.Min}} liegen{{else}}am oder vor dem {{.Max}} liegen{{end}}" +
+			"{{else}}" +
+			"{{if .Min}}{{if .ExclusiveMin}}größer als{{else}}mindestens{{end}} {{.Min}}{{end}}" +
+			"{{if and .Min .Max}} und {{end}}" +
+			"{{if .Max}}{{if .ExclusiveMax}}kleiner als{{else}}höchstens{{end}} {{.Max}}{{end}} sein" +
+			"{{end}}",
+		ERROR_PATTERN_MISMATCH: "Das Feld {{.Label}} hat nicht das richtige Format",
+		ERROR_INVALID_CHOICE:   "Das Feld {{.Label}} muss einer der folgenden Werte sein: {{.Choices}}",
+		ERROR_TOO_FEW_ITEMS:    "Das Feld {{.Label}} muss mindestens {{.Min}} Werte haben",
+		ERROR_TOO_MANY_ITEMS:   "Das Feld {{.Label}} darf höchstens {{.Max}} Werte haben",
+		ERROR_CUSTOM:           "Ungültiger Wert für das Feld {{.Label}}",
+		ERROR_TIMEOUT:          "Die Überprüfung des Feldes {{.Label}} hat zu lange gedauert",
+		ERROR_CANCELED:         "Die Überprüfung des Feldes {{.Label}} wurde abgebrochen",
+		ERROR_FILE_TYPE:        "Die Datei {{.Label}} konnte nicht gelesen werden",
+		ERROR_FILE_TOO_LARGE:   "Die Datei {{.Label}} darf höchstens {{.Max}} groß sein",
+		ERROR_FILE_EXTENSION:   "Die Datei {{.Label}} muss eine der folgenden Endungen haben: {{.Choices}}",
+		ERROR_FILE_MIME_TYPE:   "Die Datei {{.Label}} muss vom Typ {{.Choices}} sein",
+		ERROR_IMAGE_TOO_SMALL:  "Das Bild {{.Label}} muss mindestens {{if .Width}}{{.Width}} Pixel breit{{end}}{{if and .Width .Height}} und {{end}}{{if .Height}}{{.Height}} Pixel hoch{{end}} sein",
+		ERROR_IMAGE_TOO_LARGE:  "Das Bild {{.Label}} darf höchstens {{if .Width}}{{.Width}} Pixel breit{{end}}{{if and .Width .Height}} und {{end}}{{if .Height}}{{.Height}} Pixel hoch{{end}} sein",
+		ERROR_ASPECT_RATIO:     "Das Bild {{.Label}} muss ein Seitenverhältnis von {{.Ratio}} haben",
+		ERROR_TOO_MANY_PIXELS:  "Das Bild {{.Label}} darf höchstens {{.Max}} Pixel haben",
+		ERROR_TOO_FEW_FILES:    "Das Feld {{.Label}} muss mindestens {{.Min}} Dateien haben",
+		ERROR_TOO_MANY_FILES:   "Das Feld {{.Label}} darf höchstens {{.Max}} Dateien haben",
+		ERROR_FILES_TOO_LARGE:  "Die Dateien {{.Label}} dürfen insgesamt höchstens {{.Max}} groß sein",
+	},
+	"fr": {
+		ERROR_MISSING_VALUE:       "Le champ {{.Label}} est obligatoire",
+		ERROR_INCORRECT_TYPE:      "Le champ {{.Label}} attend une valeur de type {{.Type}}",
+		ERROR_FIELDS_DO_NOT_MATCH: "Les champs {{.Label}} et {{.MatchesLabel}} doivent correspondre.",
+		ERROR_TOO_SHORT:           "Le champ {{.Label}} doit contenir {{if .Length}}exactement {{.Length}}{{else}}au moins {{.Min}}{{end}} caractères",
+		ERROR_TOO_LONG:            "Le champ {{.Label}} doit contenir {{if .Length}}exactement {{.Length}}{{else}}au plus {{.Max}}{{end}} caractères",
+		ERROR_OUT_OF_RANGE: "Le champ {{.Label}} doit être " +
+			"{{if .Date}}" +
+			"{{if and .Min .Max}}entre le {{.Min}} et le {{.Max}}{{else if .Min}}le {{.Min}} ou après{{else}}le {{.Max}} ou avant{{end}}" +
+			"{{else}}" +
+			"{{if .Min}}{{if .ExclusiveMin}}supérieur à{{else}}au moins{{end}} {{.Min}}{{end}}" +
+			"{{if and .Min .Max}} et {{end}}" +
+			"{{if .Max}}{{if .ExclusiveMax}}inférieur à{{else}}au plus{{end}} {{.Max}}{{end}}" +
+			"{{end}}",
+		ERROR_PATTERN_MISMATCH: "Le champ {{.Label}} n'est pas au bon format",
+		ERROR_INVALID_CHOICE:   "Le champ {{.Label}} doit être l'une des valeurs suivantes : {{.Choices}}",
+		ERROR_TOO_FEW_ITEMS:    "Le champ {{.Label}} doit avoir au moins {{.Min}} valeurs",
+		ERROR_TOO_MANY_ITEMS:   "Le champ {{.Label}} doit avoir au plus {{.Max}} valeurs",
+		ERROR_CUSTOM:           "Valeur invalide pour le champ {{.Label}}",
+		ERROR_TIMEOUT:          "La validation du champ {{.Label}} a expiré",
+		ERROR_CANCELED:         "La validation du champ {{.Label}} a été annulée",
+		ERROR_FILE_TYPE:        "Le fichier {{.Label}} n'a pas pu être lu",
+		ERROR_FILE_TOO_LARGE:   "Le fichier {{.Label}} ne doit pas dépasser {{.Max}}",
+		ERROR_FILE_EXTENSION:   "Le fichier {{.Label}} doit avoir l'une des extensions suivantes : {{.Choices}}",
+		ERROR_FILE_MIME_TYPE:   "Le fichier {{.Label}} doit être de type {{.Choices}}",
+		ERROR_IMAGE_TOO_SMALL:  "L'image {{.Label}} doit mesurer au moins {{if .Width}}{{.Width}} pixels de large{{end}}{{if and .Width .Height}} et {{end}}{{if .Height}}{{.Height}} pixels de haut{{end}}",
+		ERROR_IMAGE_TOO_LARGE:  "L'image {{.Label}} doit mesurer au plus {{if .Width}}{{.Width}} pixels de large{{end}}{{if and .Width .Height}} et {{end}}{{if .Height}}{{.Height}} pixels de haut{{end}}",
+		ERROR_ASPECT_RATIO:     "L'image {{.Label}} doit avoir un rapport d'aspect de {{.Ratio}}",
+		ERROR_TOO_MANY_PIXELS:  "L'image {{.Label}} doit avoir au plus {{.Max}} pixels",
+		ERROR_TOO_FEW_FILES:    "Le champ {{.Label}} doit avoir au moins {{.Min}} fichiers",
+		ERROR_TOO_MANY_FILES:   "Le champ {{.Label}} doit avoir au plus {{.Max}} fichiers",
+		ERROR_FILES_TOO_LARGE:  "Les fichiers {{.Label}} ne doivent pas dépasser {{.Max}} au total",
+	},
+	"es": {
+		ERROR_MISSING_VALUE:       "El campo {{.Label}} es obligatorio",
+		ERROR_INCORRECT_TYPE:      "El campo {{.Label}} espera un valor de tipo {{.Type}}",
+		ERROR_FIELDS_DO_NOT_MATCH: "Los campos {{.Label}} y {{.MatchesLabel}} deben coincidir.",
+		ERROR_TOO_SHORT:           "El campo {{.Label}} debe tener {{if .Length}}exactamente {{.Length}}{{else}}al menos {{.Min}}{{end}} caracteres",
+		ERROR_TOO_LONG:            "El campo {{.Label}} debe tener {{if .Length}}exactamente {{.Length}}{{else}}como máximo {{.Max}}{{end}} caracteres",
+		ERROR_OUT_OF_RANGE: "El campo {{.Label}} debe ser " +
+			"{{if .Date}}" +
+			"{{if and .Min .Max}}entre el {{.Min}} y el {{.Max}}{{else if .Min}}el {{.Min}} o posterior{{else}}el {{.Max}} o anterior{{end}}" +
+			"{{else}}" +
+			"{{if .Min}}{{if .ExclusiveMin}}mayor que{{else}}al menos{{end}} {{.Min}}{{end}}" +
+			"{{if and .Min .Max}} y {{end}}" +
+			"{{if .Max}}{{if .ExclusiveMax}}menor que{{else}}como máximo{{end}} {{.Max}}{{end}}" +
+			"{{end}}",
+		ERROR_PATTERN_MISMATCH: "El campo {{.Label}} no tiene el formato correcto",
+		ERROR_INVALID_CHOICE:   "El campo {{.Label}} debe ser uno de {{.Choices}}",
+		ERROR_TOO_FEW_ITEMS:    "El campo {{.Label}} debe tener al menos {{.Min}} valores",
+		ERROR_TOO_MANY_ITEMS:   "El campo {{.Label}} debe tener como máximo {{.Max}} valores",
+		ERROR_CUSTOM:           "Valor no válido para el campo {{.Label}}",
+		ERROR_TIMEOUT:          "La validación del campo {{.Label}} ha excedido el tiempo de espera",
+		ERROR_CANCELED:         "La validación del campo {{.Label}} fue cancelada",
+		ERROR_FILE_TYPE:        "No se pudo leer el archivo {{.Label}}",
+		ERROR_FILE_TOO_LARGE:   "El archivo {{.Label}} debe ocupar como máximo {{.Max}}",
+		ERROR_FILE_EXTENSION:   "El archivo {{.Label}} debe tener una de las extensiones {{.Choices}}",
+		ERROR_FILE_MIME_TYPE:   "El archivo {{.Label}} debe ser de tipo {{.Choices}}",
+		ERROR_IMAGE_TOO_SMALL:  "La imagen {{.Label}} debe medir al menos {{if .Width}}{{.Width}} píxeles de ancho{{end}}{{if and .Width .Height}} y {{end}}{{if .Height}}{{.Height}} píxeles de alto{{end}}",
+		ERROR_IMAGE_TOO_LARGE:  "La imagen {{.Label}} debe medir como máximo {{if .Width}}{{.Width}} píxeles de ancho{{end}}{{if and .Width .Height}} y {{end}}{{if .Height}}{{.Height}} píxeles de alto{{end}}",
+		ERROR_ASPECT_RATIO:     "La imagen {{.Label}} debe tener una relación de aspecto de {{.Ratio}}",
+		ERROR_TOO_MANY_PIXELS:  "La imagen {{.Label}} debe tener como máximo {{.Max}} píxeles",
+		ERROR_TOO_FEW_FILES:    "El campo {{.Label}} debe tener al menos {{.Min}} archivos",
+		ERROR_TOO_MANY_FILES:   "El campo {{.Label}} debe tener como máximo {{.Max}} archivos",
+		ERROR_FILES_TOO_LARGE:  "Los archivos {{.Label}} deben ocupar como máximo {{.Max}} en total",
+	},
+}
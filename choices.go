@@ -0,0 +1,72 @@
+package form_validator
+
+// Choice is a single option of a select or radio input. The Label defaults to the Value
+type Choice struct {
+	Value string
+	Label string
+}
+
+// Option is a `Choice` ready to be rendered by a template
+type Option struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+func (c Choice) label() string {
+	if c.Label != "" {
+		return c.Label
+	}
+	return c.Value
+}
+
+// checkChoices checks the raw submitted value is the value of one of the field's Choices
+func checkChoices(f *Field) {
+	if len(f.Choices) == 0 {
+		return
+	}
+	for _, choice := range f.Choices {
+		if choice.Value == f.Initial {
+			return
+		}
+	}
+	addError(f, ERROR_INVALID_CHOICE)
+}
+
+// GetOptions gets the field's Choices as options, the option matching the submitted
+// value (or the field's Default) is selected. The options of multi value fields
+// are selected for each submitted value
+//
+//	options := GetOptions("country", &c)
+//
+// If the options are passed to the template as data then the select's
+// options can be rendered with the submitted value pre-selected, for example:
+//
+//	<select name="country">
+//		{{ range .Options }}
+//			<option value="{{ .Value }}" {{ if .Selected }}selected{{ end }}>{{ .Label }}</option>
+//		{{ end }}
+//	</select>
+func GetOptions(name string, c *Config) []Option {
+	var options []Option
+	for _, f := range c.Fields {
+		if f.Name != name {
+			continue
+		}
+		selected := map[string]bool{setValueToInitialOrDefault(&f): true}
+		if isMultiValue(&f) {
+			selected = map[string]bool{}
+			for _, v := range f.Initials {
+				selected[v] = true
+			}
+		}
+		for _, choice := range f.Choices {
+			options = append(options, Option{
+				Value:    choice.Value,
+				Label:    choice.label(),
+				Selected: selected[choice.Value],
+			})
+		}
+	}
+	return options
+}
@@ -0,0 +1,58 @@
+package form_validator
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var countryField = Field{
+	Name:     "country",
+	Validate: true,
+	Type:     "string",
+	Default:  "gb",
+	Choices: []Choice{
+		{Value: "gb", Label: "United Kingdom"},
+		{Value: "fr", Label: "France"},
+		{Value: "pl"},
+	},
+}
+
+func TestChoices(t *testing.T) {
+	s := NewSchema(&Config{Fields: []Field{countryField}})
+
+	data := url.Values{}
+	data.Set("country", "fr")
+	res := s.Validate(newFormRequest(data))
+	assert.True(t, res.Valid())
+	assert.Equal(t, "fr", res.Value("country"))
+
+	data.Set("country", "de")
+	res = s.Validate(newFormRequest(data))
+	assert.False(t, res.Valid())
+	assert.Equal(t, ERROR_INVALID_CHOICE, res.Error("country").Type)
+	assert.Equal(t, "country field must be one of United Kingdom, France, pl", res.Error("country").Message)
+}
+
+func TestGetOptions(t *testing.T) {
+	s := NewSchema(&Config{Fields: []Field{countryField}})
+	tmpl := template.Must(template.New("select").Parse(
+		`{{ range .Options }}<option value="{{ .Value }}"{{ if .Selected }} selected{{ end }}>{{ .Label }}</option>{{ end }}`,
+	))
+
+	data := url.Values{}
+	data.Set("country", "fr")
+	res := s.Validate(newFormRequest(data))
+	var sb strings.Builder
+	err := tmpl.Execute(&sb, map[string]interface{}{"Options": GetOptions("country", res.Config())})
+	assert.NoError(t, err)
+	assert.Equal(t, `<option value="gb">United Kingdom</option><option value="fr" selected>France</option><option value="pl">pl</option>`, sb.String())
+
+	// The Default is selected before the form is submitted
+	options := GetOptions("country", &Config{Fields: []Field{countryField}})
+	assert.True(t, options[0].Selected)
+	assert.Nil(t, GetOptions("missing", res.Config()))
+}
@@ -0,0 +1,277 @@
+package form_validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// numericType describes how the values of a numeric `Field.Type` are parsed & compared
+type numericType struct {
+	kind byte // 'i' for signed, 'u' for unsigned & 'f' for floating point types
+	bits int
+}
+
+var numericTypes = map[string]numericType{
+	"int":     {'i', strconv.IntSize},
+	"int8":    {'i', 8},
+	"int16":   {'i', 16},
+	"int32":   {'i', 32},
+	"int64":   {'i', 64},
+	"uint":    {'u', strconv.IntSize},
+	"uint8":   {'u', 8},
+	"uint16":  {'u', 16},
+	"uint32":  {'u', 32},
+	"uint64":  {'u', 64},
+	"float32": {'f', 32},
+	"float64": {'f', 64},
+}
+
+// number holds a numeric value in the representation of its type so int64 & uint64
+// values are compared without losing precision to float64
+type number struct {
+	kind byte
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// parse parses s as a number of the numeric type
+func (t numericType) parse(s string) (number, error) {
+	n := number{kind: t.kind}
+	var err error
+	switch t.kind {
+	case 'i':
+		n.i, err = strconv.ParseInt(s, 10, t.bits)
+	case 'u':
+		n.u, err = strconv.ParseUint(s, 10, t.bits)
+	default:
+		n.f, err = strconv.ParseFloat(s, t.bits)
+	}
+	return n, err
+}
+
+// limits returns the smallest & largest values of the numeric type
+func (t numericType) limits() (string, string) {
+	switch t.kind {
+	case 'i':
+		max := int64(math.MaxInt64 >> (64 - t.bits))
+		return strconv.FormatInt(-max-1, 10), strconv.FormatInt(max, 10)
+	case 'u':
+		return "0", strconv.FormatUint(math.MaxUint64>>(64-t.bits), 10)
+	}
+	max := math.MaxFloat64
+	if t.bits == 32 {
+		max = math.MaxFloat32
+	}
+	return strconv.FormatFloat(-max, 'g', -1, t.bits), strconv.FormatFloat(max, 'g', -1, t.bits)
+}
+
+// toNumber converts a value produced by `convertToType` to a number
+func toNumber(v interface{}) (number, bool) {
+	switch n := v.(type) {
+	case int:
+		return number{kind: 'i', i: int64(n)}, true
+	case int8:
+		return number{kind: 'i', i: int64(n)}, true
+	case int16:
+		return number{kind: 'i', i: int64(n)}, true
+	case int32:
+		return number{kind: 'i', i: int64(n)}, true
+	case int64:
+		return number{kind: 'i', i: n}, true
+	case uint:
+		return number{kind: 'u', u: uint64(n)}, true
+	case uint8:
+		return number{kind: 'u', u: uint64(n)}, true
+	case uint16:
+		return number{kind: 'u', u: uint64(n)}, true
+	case uint32:
+		return number{kind: 'u', u: uint64(n)}, true
+	case uint64:
+		return number{kind: 'u', u: n}, true
+	case float32:
+		return number{kind: 'f', f: float64(n)}, true
+	case float64:
+		return number{kind: 'f', f: n}, true
+	}
+	return number{}, false
+}
+
+// cmp returns -1, 0 or +1 depending on whether n is less than, equal to or greater than o.
+// Both numbers must be of the same kind
+func (n number) cmp(o number) int {
+	switch {
+	case n.kind == 'i' && n.i < o.i, n.kind == 'u' && n.u < o.u, n.kind == 'f' && n.f < o.f:
+		return -1
+	case n.kind == 'i' && n.i > o.i, n.kind == 'u' && n.u > o.u, n.kind == 'f' && n.f > o.f:
+		return 1
+	}
+	return 0
+}
+
+// checkBounds panics if the field's Min or Max can't be parsed as the field's type,
+// it's called when a `Schema` is compiled so misconfigured fields fail at startup
+func checkBounds(f *Field) {
+	if isMultiValue(f) {
+		// The bounds of multi value fields apply to each of the values
+		item := *f
+		item.Type = strings.TrimPrefix(f.Type, "[]")
+		f = &item
+	}
+	checkDateBounds(f)
+	if f.Min == "" && f.Max == "" {
+		return
+	}
+	t, ok := numericTypes[f.Type]
+	if !ok {
+		panic(fmt.Sprintf("Min & Max are only supported by numeric types but %s field is of type %s", f.Name, f.Type))
+	}
+	for _, bound := range []string{f.Min, f.Max} {
+		if _, err := t.parse(bound); bound != "" && err != nil {
+			panic(fmt.Sprintf("Invalid Min or Max value %s for %s field: %s", bound, f.Name, err))
+		}
+	}
+}
+
+// patterns caches the compiled regular expression of each `Field.Pattern` so a
+// pattern is compiled once rather than on every request
+var patterns sync.Map
+
+// compilePattern returns the compiled pattern, anchored like the HTML pattern attribute
+// so the whole value must match. It panics if the pattern is invalid
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, _ := patterns.LoadOrStore(pattern, regexp.MustCompile(`^(?:`+pattern+`)$`))
+	return re.(*regexp.Regexp)
+}
+
+// checkDateBounds panics if the field's MinDate or MaxDate can't be parsed as the field's type
+func checkDateBounds(f *Field) {
+	if f.MinDate == "" && f.MaxDate == "" {
+		return
+	}
+	if _, ok := dateLayouts[f.Type]; !ok {
+		panic(fmt.Sprintf("MinDate & MaxDate are only supported by date & time types but %s field is of type %s", f.Name, f.Type))
+	}
+	for _, bound := range []string{f.MinDate, f.MaxDate} {
+		if _, err := parseDate(f, bound); bound != "" && err != nil {
+			panic(fmt.Sprintf("Invalid MinDate or MaxDate value %s for %s field: %s", bound, f.Name, err))
+		}
+	}
+}
+
+// checkConstraints checks a converted field value against the field's constraints.
+// The values of multi value fields are checked individually by `convertMultiValue`
+func checkConstraints(f *Field) {
+	if isMultiValue(f) {
+		return
+	}
+	for _, check := range []func(*Field){checkLength, checkRange, checkDateRange, checkPattern, checkChoices} {
+		if bail(f) {
+			return
+		}
+		check(f)
+	}
+}
+
+// checkDateRange checks date & time values against the field's MinDate & MaxDate
+func checkDateRange(f *Field) {
+	t, ok := f.Value.(time.Time)
+	if !ok {
+		return
+	}
+	if min, err := parseDate(f, f.MinDate); f.MinDate != "" && err == nil && t.Before(min) {
+		addError(f, ERROR_OUT_OF_RANGE)
+	}
+	if max, err := parseDate(f, f.MaxDate); f.MaxDate != "" && err == nil && t.After(max) {
+		addError(f, ERROR_OUT_OF_RANGE)
+	}
+}
+
+// checkPattern matches the raw submitted value against the field's Pattern
+func checkPattern(f *Field) {
+	if f.Pattern == "" {
+		return
+	}
+	if !compilePattern(f.Pattern).MatchString(f.Initial) {
+		addError(f, ERROR_PATTERN_MISMATCH)
+	}
+}
+
+// checkLength checks the length of string values in runes so multibyte
+// characters count as a single character
+func checkLength(f *Field) {
+	s, ok := f.Value.(string)
+	if !ok {
+		return
+	}
+	n := utf8.RuneCountInString(s)
+	switch {
+	case f.Length > 0 && n < f.Length:
+		addError(f, ERROR_TOO_SHORT)
+	case f.Length > 0 && n > f.Length:
+		addError(f, ERROR_TOO_LONG)
+	case f.MinLength > 0 && n < f.MinLength:
+		addError(f, ERROR_TOO_SHORT)
+	case f.MaxLength > 0 && n > f.MaxLength:
+		addError(f, ERROR_TOO_LONG)
+	}
+}
+
+// checkRange checks numeric values against the field's Min & Max
+func checkRange(f *Field) {
+	t, ok := numericTypes[f.Type]
+	if !ok || (f.Min == "" && f.Max == "") {
+		return
+	}
+	n, ok := toNumber(f.Value)
+	if !ok {
+		return
+	}
+	// NaN compares equal to every bound, so it's never within the range
+	if n.kind == 'f' && math.IsNaN(n.f) {
+		addError(f, ERROR_OUT_OF_RANGE)
+		return
+	}
+	if f.Min != "" {
+		min, _ := t.parse(f.Min)
+		if c := n.cmp(min); c < 0 || (c == 0 && f.ExclusiveMin) {
+			addError(f, ERROR_OUT_OF_RANGE)
+		}
+	}
+	if f.Max != "" {
+		max, _ := t.parse(f.Max)
+		if c := n.cmp(max); c > 0 || (c == 0 && f.ExclusiveMax) {
+			addError(f, ERROR_OUT_OF_RANGE)
+		}
+	}
+}
+
+// rangeBounds returns the bounds named in an out of range error message. When the
+// submitted value overflowed the type, missing bounds are set to the type's limits
+func rangeBounds(f *Field) (string, string) {
+	min, max := f.Min, f.Max
+	t, ok := numericTypes[f.Type]
+	if !ok {
+		return min, max
+	}
+	if _, err := t.parse(f.Initial); errors.Is(err, strconv.ErrRange) {
+		typeMin, typeMax := t.limits()
+		if min == "" {
+			min = typeMin
+		}
+		if max == "" {
+			max = typeMax
+		}
+	}
+	return min, max
+}
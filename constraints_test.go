@@ -0,0 +1,246 @@
+package form_validator
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLengthConstraints(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		field   Field
+		value   string
+		wantErr string
+		wantMsg string
+	}{
+		"within bounds": {
+			field: Field{Name: "username", Type: "string", MinLength: 3, MaxLength: 8},
+			value: "joe",
+		},
+		"too short": {
+			field:   Field{Name: "username", Type: "string", MinLength: 3, MaxLength: 8},
+			value:   "jo",
+			wantErr: ERROR_TOO_SHORT,
+			wantMsg: "username field must be at least 3 characters",
+		},
+		"too long": {
+			field:   Field{Name: "username", Type: "string", MinLength: 3, MaxLength: 8},
+			value:   "joegasewicz",
+			wantErr: ERROR_TOO_LONG,
+			wantMsg: "username field must be at most 8 characters",
+		},
+		"counts runes not bytes": {
+			field: Field{Name: "comment", MaxLength: 4},
+			value: "żółw",
+		},
+		"exact length": {
+			field: Field{Name: "code", Type: "string", Length: 4},
+			value: "ab12",
+		},
+		"not exact length": {
+			field:   Field{Name: "code", Type: "string", Length: 4},
+			value:   "ab123",
+			wantErr: ERROR_TOO_LONG,
+			wantMsg: "code field must be exactly 4 characters",
+		},
+		"empty optional value is not checked": {
+			field: Field{Name: "comment", Type: "string", MinLength: 3},
+			value: "",
+		},
+	}
+
+	for name, tt := range testcases {
+		name := name
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data := url.Values{}
+			data.Set(tt.field.Name, tt.value)
+			res := NewSchema(&Config{Fields: []Field{tt.field}}).Validate(newFormRequest(data))
+			err := res.Error(tt.field.Name)
+			assert.Equal(t, tt.wantErr, err.Type)
+			assert.Equal(t, tt.wantMsg, err.Message)
+			assert.Equal(t, tt.wantErr == "", res.Valid())
+		})
+	}
+}
+
+func TestRangeConstraints(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		field   Field
+		value   string
+		wantErr string
+		wantMsg string
+	}{
+		"within bounds": {
+			field: Field{Validate: true, Name: "age", Type: "int32", Min: "18", Max: "130"},
+			value: "18",
+		},
+		"below min": {
+			field:   Field{Validate: true, Name: "age", Type: "int32", Min: "18", Max: "130"},
+			value:   "17",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "age field must be at least 18 and at most 130",
+		},
+		"exclusive min": {
+			field:   Field{Validate: true, Name: "weight", Type: "float64", Min: "0", ExclusiveMin: true},
+			value:   "0",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "weight field must be greater than 0",
+		},
+		"exclusive max": {
+			field: Field{Validate: true, Name: "ratio", Type: "float32", Max: "1", ExclusiveMax: true},
+			value: "0.99",
+		},
+		"above max": {
+			field:   Field{Validate: true, Name: "rating", Type: "uint8", Max: "5"},
+			value:   "6",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "rating field must be at most 5",
+		},
+		"uint64 beyond float64 precision": {
+			field:   Field{Validate: true, Name: "id", Type: "uint64", Max: "18446744073709551614"},
+			value:   "18446744073709551615",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "id field must be at most 18446744073709551614",
+		},
+		"int64 beyond float64 precision": {
+			field: Field{Validate: true, Name: "id", Type: "int64", Min: "-9223372036854775807"},
+			value: "-9223372036854775807",
+		},
+		"overflow": {
+			field:   Field{Validate: true, Name: "rating", Type: "uint8"},
+			value:   "300",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "rating field must be at least 0 and at most 255",
+		},
+		"overflow with min": {
+			field:   Field{Validate: true, Name: "temperature", Type: "int8", Min: "-40"},
+			value:   "-300",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "temperature field must be at least -40 and at most 127",
+		},
+		"NaN": {
+			field:   Field{Validate: true, Name: "score", Type: "float64", Min: "0", Max: "10"},
+			value:   "NaN",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "score field must be at least 0 and at most 10",
+		},
+		"NaN with max": {
+			field:   Field{Validate: true, Name: "score", Type: "float32", Max: "10"},
+			value:   "nan",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "score field must be at most 10",
+		},
+		"Inf above max": {
+			field:   Field{Validate: true, Name: "score", Type: "float64", Max: "10"},
+			value:   "Inf",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "score field must be at most 10",
+		},
+		"-Inf below min": {
+			field:   Field{Validate: true, Name: "score", Type: "float64", Min: "0"},
+			value:   "-Inf",
+			wantErr: ERROR_OUT_OF_RANGE,
+			wantMsg: "score field must be at least 0",
+		},
+		"Inf without max": {
+			field: Field{Validate: true, Name: "score", Type: "float64", Min: "0"},
+			value: "+Inf",
+		},
+		"not a number": {
+			field:   Field{Validate: true, Name: "rating", Type: "uint8", Max: "5"},
+			value:   "five",
+			wantErr: ERROR_INCORRECT_TYPE,
+			wantMsg: "Expected a value of type uint8 for rating field",
+		},
+	}
+
+	for name, tt := range testcases {
+		name := name
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data := url.Values{}
+			data.Set(tt.field.Name, tt.value)
+			res := NewSchema(&Config{Fields: []Field{tt.field}}).Validate(newFormRequest(data))
+			err := res.Error(tt.field.Name)
+			assert.Equal(t, tt.wantErr, err.Type)
+			assert.Equal(t, tt.wantMsg, err.Message)
+		})
+	}
+}
+
+func TestRangeConstraintsMisconfigured(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "age", Type: "int", Max: "old"}}})
+	})
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "name", Type: "string", Min: "1"}}})
+	})
+}
+
+func TestPatternConstraint(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		field   Field
+		value   string
+		wantErr string
+		wantMsg string
+	}{
+		"matches": {
+			field: Field{Name: "slug", Type: "string", Pattern: "[a-z-]+"},
+			value: "form-validator",
+		},
+		"must match the whole value": {
+			field:   Field{Name: "slug", Type: "string", Pattern: "[a-z]+", PatternHint: "must contain only lowercase letters"},
+			value:   "form-validator",
+			wantErr: ERROR_PATTERN_MISMATCH,
+			wantMsg: "slug field must contain only lowercase letters",
+		},
+		"default hint": {
+			field:   Field{Name: "sku", Pattern: `[A-Z]{3}-\d{4}`},
+			value:   "ABC-12",
+			wantErr: ERROR_PATTERN_MISMATCH,
+			wantMsg: "sku field is not in the correct format",
+		},
+		"applied to the raw value": {
+			field: Field{Name: "postcode", Type: "int", Pattern: `0\d{4}`},
+			value: "01234",
+		},
+	}
+
+	for name, tt := range testcases {
+		name := name
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data := url.Values{}
+			data.Set(tt.field.Name, tt.value)
+			res := NewSchema(&Config{Fields: []Field{tt.field}}).Validate(newFormRequest(data))
+			err := res.Error(tt.field.Name)
+			assert.Equal(t, tt.wantErr, err.Type)
+			assert.Equal(t, tt.wantMsg, err.Message)
+			// Pattern errors surface in FormErrors alongside the other errors
+			assert.Equal(t, tt.wantMsg, res.Errors()[tt.field.Name]["error"])
+		})
+	}
+}
+
+func TestPatternMisconfigured(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "slug", Pattern: "[a-z"}}})
+	})
+}
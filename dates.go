@@ -0,0 +1,71 @@
+package form_validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts holds the default layout of each date & time type, matching the values
+// submitted by the HTML input of the same type
+var dateLayouts = map[string]string{
+	"date":           "2006-01-02",
+	"datetime-local": "2006-01-02T15:04",
+	"time":           "15:04",
+	"month":          "2006-01",
+	"week":           "2006-W01",
+}
+
+// dateLayout returns the field's Layout or the default layout of its type
+func dateLayout(f *Field) string {
+	if f.Layout != "" {
+		return f.Layout
+	}
+	return dateLayouts[f.Type]
+}
+
+// parseDate parses s in the field's layout & location, which defaults to UTC
+func parseDate(f *Field, s string) (time.Time, error) {
+	loc := f.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	if f.Type == "week" && f.Layout == "" {
+		return parseWeek(s, loc)
+	}
+	layout := dateLayout(f)
+	t, err := time.ParseInLocation(layout, s, loc)
+	if err != nil && f.Layout == "" && (f.Type == "datetime-local" || f.Type == "time") {
+		// Browsers include the seconds when the input's step is less than a minute
+		t, err = time.ParseInLocation(layout+":05", s, loc)
+	}
+	return t, err
+}
+
+// parseWeek parses an ISO 8601 week such as "2024-W05" to the Monday the week starts on
+func parseWeek(s string, loc *time.Location) (time.Time, error) {
+	year, week, ok := strings.Cut(s, "-W")
+	y, yErr := strconv.Atoi(year)
+	w, wErr := strconv.Atoi(week)
+	if !ok || len(year) != 4 || len(week) != 2 || yErr != nil || wErr != nil || w < 1 || w > 53 {
+		return time.Time{}, fmt.Errorf("parsing week %q: expected the format 2006-W01", s)
+	}
+	// The 4th of January is always in the first week of the year
+	jan4 := time.Date(y, time.January, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7+(w-1)*7)
+	if isoYear, isoWeek := monday.ISOWeek(); isoYear != y || isoWeek != w {
+		return time.Time{}, fmt.Errorf("parsing week %q: %d has no week %d", s, y, w)
+	}
+	return monday, nil
+}
+
+// convertDate converts the date & time types to a time.Time
+func convertDate(f *Field) {
+	initialOrDefault := setValueToInitialOrDefault(f)
+	t, err := parseDate(f, initialOrDefault)
+	if err != nil {
+		setConversionError(f, initialOrDefault, err)
+	}
+	f.Value = t
+}
@@ -0,0 +1,136 @@
+package form_validator
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateConversion(t *testing.T) {
+	t.Parallel()
+
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+
+	testcases := map[string]struct {
+		field     Field
+		value     string
+		wantValue time.Time
+		wantErr   string
+		wantMsg   string
+	}{
+		"date": {
+			field:     Field{Type: "date"},
+			value:     "2024-02-29",
+			wantValue: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"invalid date": {
+			field:   Field{Type: "date"},
+			value:   "2023-02-29",
+			wantErr: ERROR_INCORRECT_TYPE,
+			wantMsg: "Expected a value of type date for value field",
+		},
+		"datetime-local in location": {
+			field:     Field{Type: "datetime-local", Location: london},
+			value:     "2024-07-01T09:30",
+			wantValue: time.Date(2024, 7, 1, 9, 30, 0, 0, london),
+		},
+		"datetime-local with seconds": {
+			field:     Field{Type: "datetime-local"},
+			value:     "2024-07-01T09:30:15",
+			wantValue: time.Date(2024, 7, 1, 9, 30, 15, 0, time.UTC),
+		},
+		"time": {
+			field:     Field{Type: "time"},
+			value:     "23:59",
+			wantValue: time.Date(0, 1, 1, 23, 59, 0, 0, time.UTC),
+		},
+		"month": {
+			field:     Field{Type: "month"},
+			value:     "2024-12",
+			wantValue: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"week": {
+			field:     Field{Type: "week"},
+			value:     "2025-W01",
+			wantValue: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		"week 53 of a 52 week year": {
+			field:   Field{Type: "week"},
+			value:   "2023-W53",
+			wantErr: ERROR_INCORRECT_TYPE,
+			wantMsg: "Expected a value of type week for value field",
+		},
+		"custom layout": {
+			field:     Field{Type: "date", Layout: "02/01/2006"},
+			value:     "25/12/2024",
+			wantValue: time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+		},
+		"before min date": {
+			field:     Field{Type: "date", MinDate: "2024-01-01"},
+			value:     "2023-12-31",
+			wantValue: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			wantErr:   ERROR_OUT_OF_RANGE,
+			wantMsg:   "value field must be on or after 2024-01-01",
+		},
+		"after max date": {
+			field:     Field{Type: "month", MinDate: "2024-01", MaxDate: "2024-06"},
+			value:     "2024-07",
+			wantValue: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:   ERROR_OUT_OF_RANGE,
+			wantMsg:   "value field must be between 2024-01 and 2024-06",
+		},
+		"on max date": {
+			field:     Field{Type: "date", MaxDate: "2024-06-30"},
+			value:     "2024-06-30",
+			wantValue: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tt := range testcases {
+		name := name
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.field.Name = "value"
+			tt.field.Validate = true
+			data := url.Values{}
+			data.Set("value", tt.value)
+			res := NewSchema(&Config{Fields: []Field{tt.field}}).Validate(newFormRequest(data))
+			assert.Equal(t, tt.wantErr, res.Error("value").Type)
+			assert.Equal(t, tt.wantMsg, res.Error("value").Message)
+			if !tt.wantValue.IsZero() {
+				assert.True(t, tt.wantValue.Equal(res.Value("value").(time.Time)), "got %v", res.Value("value"))
+			}
+		})
+	}
+}
+
+func TestUnvalidatedDateRange(t *testing.T) {
+	c := Config{Fields: []Field{{Name: "start", Type: "date", MinDate: "2024-01-01"}}}
+
+	data := url.Values{}
+	data.Set("start", "1999-01-01")
+	assert.False(t, ValidateValues(data, nil, &c))
+	assert.Equal(t, ERROR_OUT_OF_RANGE, c.Fields[0].Error.Type)
+	assert.Equal(t, "start field must be on or after 2024-01-01", c.Fields[0].Error.Message)
+
+	data.Set("start", "2024-06-01")
+	assert.True(t, ValidateValues(data, nil, &c))
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), c.Fields[0].Value)
+}
+
+func TestDateBoundsMisconfigured(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "birthday", Type: "date", MinDate: "01/01/2000"}}})
+	})
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "age", Type: "int", MaxDate: "2000-01-01"}}})
+	})
+}
@@ -1,54 +1,290 @@
 package form_validator
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 const (
 	ERROR_MISSING_VALUE       = "ERROR_MISSING_VALUE"
 	ERROR_INCORRECT_TYPE      = "ERROR_INCORRECT_TYPE"
 	ERROR_FILE_TYPE           = "ERROR_FILE_TYPE"
 	ERROR_FIELDS_DO_NOT_MATCH = "ERROR_FIELDS_DO_NOT_MATCH"
+	ERROR_TOO_SHORT           = "ERROR_TOO_SHORT"
+	ERROR_TOO_LONG            = "ERROR_TOO_LONG"
+	ERROR_OUT_OF_RANGE        = "ERROR_OUT_OF_RANGE"
+	ERROR_PATTERN_MISMATCH    = "ERROR_PATTERN_MISMATCH"
+	ERROR_INVALID_CHOICE      = "ERROR_INVALID_CHOICE"
+	ERROR_TOO_FEW_ITEMS       = "ERROR_TOO_FEW_ITEMS"
+	ERROR_TOO_MANY_ITEMS      = "ERROR_TOO_MANY_ITEMS"
+	ERROR_CUSTOM              = "ERROR_CUSTOM"
+	ERROR_TIMEOUT             = "ERROR_TIMEOUT"
+	ERROR_CANCELED            = "ERROR_CANCELED"
+	ERROR_FILE_TOO_LARGE      = "ERROR_FILE_TOO_LARGE"
+	ERROR_FILE_EXTENSION      = "ERROR_FILE_EXTENSION"
+	ERROR_FILE_MIME_TYPE      = "ERROR_FILE_MIME_TYPE"
+	ERROR_IMAGE_TOO_SMALL     = "ERROR_IMAGE_TOO_SMALL"
+	ERROR_IMAGE_TOO_LARGE     = "ERROR_IMAGE_TOO_LARGE"
+	ERROR_ASPECT_RATIO        = "ERROR_ASPECT_RATIO"
+	ERROR_TOO_MANY_PIXELS     = "ERROR_TOO_MANY_PIXELS"
+	ERROR_TOO_FEW_FILES       = "ERROR_TOO_FEW_FILES"
+	ERROR_TOO_MANY_FILES      = "ERROR_TOO_MANY_FILES"
+	ERROR_FILES_TOO_LARGE     = "ERROR_FILES_TOO_LARGE"
+)
+
+// Sentinel errors for each of the error types, a `FieldError` matches the sentinel
+// of its Type with errors.Is
+//
+//	if errors.Is(err, form_validator.ErrMissingValue) {
+//		// a field is missing
+//	}
+var (
+	ErrMissingValue     = errors.New("form_validator: missing value")
+	ErrIncorrectType    = errors.New("form_validator: incorrect type")
+	ErrFileType         = errors.New("form_validator: file error")
+	ErrFieldsDoNotMatch = errors.New("form_validator: fields do not match")
+	ErrTooShort         = errors.New("form_validator: too short")
+	ErrTooLong          = errors.New("form_validator: too long")
+	ErrOutOfRange       = errors.New("form_validator: out of range")
+	ErrPatternMismatch  = errors.New("form_validator: pattern mismatch")
+	ErrInvalidChoice    = errors.New("form_validator: invalid choice")
+	ErrTooFewItems      = errors.New("form_validator: too few items")
+	ErrTooManyItems     = errors.New("form_validator: too many items")
+	ErrCustom           = errors.New("form_validator: custom validation failed")
+	ErrTimeout          = errors.New("form_validator: validation timed out")
+	ErrCanceled         = errors.New("form_validator: validation canceled")
+	ErrFileTooLarge     = errors.New("form_validator: file too large")
+	ErrFileExtension    = errors.New("form_validator: file extension not allowed")
+	ErrFileMIMEType     = errors.New("form_validator: file type not allowed")
+	ErrImageTooSmall    = errors.New("form_validator: image too small")
+	ErrImageTooLarge    = errors.New("form_validator: image too large")
+	ErrAspectRatio      = errors.New("form_validator: incorrect aspect ratio")
+	ErrTooManyPixels    = errors.New("form_validator: too many pixels")
+	ErrTooFewFiles      = errors.New("form_validator: too few files")
+	ErrTooManyFiles     = errors.New("form_validator: too many files")
+	ErrFilesTooLarge    = errors.New("form_validator: files too large")
+	// ErrInvalidBody is returned by `Validate` & `Result.Err` for a JSON body that can't be decoded
+	// or a streamed multipart form that can't be read
+	ErrInvalidBody = errors.New("form_validator: invalid request body")
+	sentinelErrors = map[string]error{
+		ERROR_MISSING_VALUE:       ErrMissingValue,
+		ERROR_INCORRECT_TYPE:      ErrIncorrectType,
+		ERROR_FILE_TYPE:           ErrFileType,
+		ERROR_FIELDS_DO_NOT_MATCH: ErrFieldsDoNotMatch,
+		ERROR_TOO_SHORT:           ErrTooShort,
+		ERROR_TOO_LONG:            ErrTooLong,
+		ERROR_OUT_OF_RANGE:        ErrOutOfRange,
+		ERROR_PATTERN_MISMATCH:    ErrPatternMismatch,
+		ERROR_INVALID_CHOICE:      ErrInvalidChoice,
+		ERROR_TOO_FEW_ITEMS:       ErrTooFewItems,
+		ERROR_TOO_MANY_ITEMS:      ErrTooManyItems,
+		ERROR_CUSTOM:              ErrCustom,
+		ERROR_TIMEOUT:             ErrTimeout,
+		ERROR_CANCELED:            ErrCanceled,
+		ERROR_FILE_TOO_LARGE:      ErrFileTooLarge,
+		ERROR_FILE_EXTENSION:      ErrFileExtension,
+		ERROR_FILE_MIME_TYPE:      ErrFileMIMEType,
+		ERROR_IMAGE_TOO_SMALL:     ErrImageTooSmall,
+		ERROR_IMAGE_TOO_LARGE:     ErrImageTooLarge,
+		ERROR_ASPECT_RATIO:        ErrAspectRatio,
+		ERROR_TOO_MANY_PIXELS:     ErrTooManyPixels,
+		ERROR_TOO_FEW_FILES:       ErrTooFewFiles,
+		ERROR_TOO_MANY_FILES:      ErrTooManyFiles,
+		ERROR_FILES_TOO_LARGE:     ErrFilesTooLarge,
+	}
 )
 
+// FieldError is a single error of a form field as an `error`. It unwraps to the
+// sentinel error of its Type, custom error types have no sentinel error
 type FieldError struct {
-	Name  string
-	Error Error
+	Name    string
+	Type    string
+	Message string
+}
+
+// Error implements `error`
+func (fe *FieldError) Error() string {
+	return fe.Message
+}
+
+// Unwrap returns the sentinel error of the error's Type, e.g. `ErrTooShort`
+func (fe *FieldError) Unwrap() error {
+	return sentinelErrors[fe.Type]
+}
+
+// ValidationError holds every error of an invalid form, in the order of the fields.
+// It unwraps to its `FieldError`s so errors.Is & errors.As match any of them
+//
+//	var ve *form_validator.ValidationError
+//	if errors.As(err, &ve) {
+//		for _, fe := range ve.Errors {
+//			log.Println(fe.Name, fe.Message)
+//		}
+//	}
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+// Error implements `error`
+func (ve *ValidationError) Error() string {
+	messages := make([]string, len(ve.Errors))
+	for i, fe := range ve.Errors {
+		messages[i] = fe.Message
+	}
+	return "form_validator: invalid form: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the field errors
+func (ve *ValidationError) Unwrap() []error {
+	errs := make([]error, len(ve.Errors))
+	for i, fe := range ve.Errors {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// validationError returns a `*ValidationError` holding the errors of the form's fields
+// or nil if the form is valid
+func validationError(c *Config) error {
+	ve := &ValidationError{}
+	for _, f := range c.Fields {
+		for _, err := range f.Errors {
+			ve.Errors = append(ve.Errors, &FieldError{Name: f.Name, Type: err.Type, Message: err.Message})
+		}
+	}
+	if len(ve.Errors) == 0 {
+		return nil
+	}
+	return ve
 }
 
 // FormErrors type enables the caller to access all the form errors
 // from a map indexed by name.
 type FormErrors map[string]map[string]string
 
-func missingValueError(name string) string {
-	return fmt.Sprintf("Missing value for %s field", name)
+// Messages returns all the error messages of a field in the order they occurred
+func (fe FormErrors) Messages(name string) []string {
+	var messages []string
+	for i := 0; ; i++ {
+		message, ok := fe[name][fmt.Sprintf("error.%d", i)]
+		if !ok {
+			break
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 && fe[name]["error"] != "" {
+		messages = append(messages, fe[name]["error"])
+	}
+	return messages
+}
+
+// setErrorMessage sets the message of the field's error from the field's Messages, or else its
+// translation into the request's locale, or else the Config's Messages or the built-in English message
+func setErrorMessage(f *Field) {
+	if executeMessage(f, f.messages[f.Error.Type]) || f.localizer.translate(f) {
+		return
+	}
+	if !executeMessage(f, f.configMessages[f.Error.Type]) {
+		executeMessage(f, defaultMessages[f.Error.Type])
+	}
 }
 
-func incorrectTypeError(fieldType, name string) string {
-	return fmt.Sprintf("Expected a value of type %s for %s field", fieldType, name)
+// matchesLabel returns the label of the field that f must match
+func matchesLabel(f *Field) string {
+	if f.matchesLabel != "" {
+		return f.matchesLabel
+	}
+	return f.Matches
 }
 
-func fileError(err error) string {
-	return fmt.Sprintf("File error: %e", err)
+// messageParams returns the parameters of the field's error message
+func messageParams(f *Field) MessageParams {
+	params := MessageParams{
+		Name:         f.Name,
+		Label:        f.label(),
+		Type:         f.Type,
+		Value:        f.Initial,
+		ExclusiveMin: f.ExclusiveMin,
+		ExclusiveMax: f.ExclusiveMax,
+		Matches:      f.Matches,
+		MatchesLabel: matchesLabel(f),
+		Hint:         f.PatternHint,
+	}
+	switch f.Error.Type {
+	case ERROR_TOO_SHORT, ERROR_TOO_LONG:
+		params.Length = f.Length
+		params.Min = strconv.Itoa(f.MinLength)
+		params.Max = strconv.Itoa(f.MaxLength)
+	case ERROR_OUT_OF_RANGE:
+		if _, ok := dateLayouts[f.Type]; ok {
+			params.Date = true
+			params.Min, params.Max = f.MinDate, f.MaxDate
+		} else {
+			params.Min, params.Max = rangeBounds(f)
+		}
+	case ERROR_TOO_FEW_ITEMS, ERROR_TOO_MANY_ITEMS:
+		params.Min = strconv.Itoa(f.MinItems)
+		params.Max = strconv.Itoa(f.MaxItems)
+	case ERROR_INVALID_CHOICE:
+		labels := make([]string, len(f.Choices))
+		for i, choice := range f.Choices {
+			labels[i] = choice.label()
+		}
+		params.Choices = strings.Join(labels, ", ")
+	case ERROR_FILE_TOO_LARGE:
+		params.Max = formatBytes(f.MaxFileSize)
+	case ERROR_FILE_EXTENSION:
+		params.Choices = strings.Join(f.AllowedExtensions, ", ")
+	case ERROR_FILE_MIME_TYPE:
+		params.Choices = strings.Join(f.AllowedMIMETypes, ", ")
+	case ERROR_IMAGE_TOO_SMALL:
+		params.Width, params.Height = f.MinWidth, f.MinHeight
+	case ERROR_IMAGE_TOO_LARGE:
+		params.Width, params.Height = f.MaxWidth, f.MaxHeight
+	case ERROR_ASPECT_RATIO:
+		params.Ratio = f.AspectRatio
+	case ERROR_TOO_MANY_PIXELS:
+		params.Max = strconv.FormatInt(f.MaxPixels, 10)
+	case ERROR_TOO_FEW_FILES, ERROR_TOO_MANY_FILES:
+		params.Min = strconv.Itoa(f.MinFiles)
+		params.Max = strconv.Itoa(f.MaxFiles)
+	case ERROR_FILES_TOO_LARGE:
+		params.Max = formatBytes(f.MaxTotalFileSize)
+	}
+	return params
 }
 
-func fieldsDoNotMatch(field, matchedField string) string {
-	return fmt.Sprintf("Fields %s and %s should match.", field, matchedField)
+// addError adds an error of errType to the field's errors with the built-in message of the type
+func addError(f *Field, errType string) {
+	appendError(f, Error{Type: errType})
 }
 
-func setErrorMessage(f *Field, fileErr error) {
-	switch f.Error.Type {
-	case ERROR_MISSING_VALUE:
-		f.Error.Message = missingValueError(f.Name)
-		break
-	case ERROR_INCORRECT_TYPE:
-		f.Error.Message = incorrectTypeError(f.Type, f.Name)
-		break
-	case ERROR_FILE_TYPE:
-		f.Error.Message = fileError(fileErr)
-	case ERROR_FIELDS_DO_NOT_MATCH:
-		f.Error.Message = fieldsDoNotMatch(f.Name, f.Matches)
-	default:
-		// pass
+// appendError appends err to the field's errors. The Message defaults to the built-in
+// message of the error's Type. The field's `Error` is always the first of its errors
+func appendError(f *Field, err Error) {
+	f.Error = err
+	if f.Error.Message == "" {
+		setErrorMessage(f)
+	}
+	if f.Error.Message == "" {
+		// Custom error types have no built-in message
+		executeMessage(f, defaultMessages[ERROR_CUSTOM])
 	}
+	f.Errors = append(f.Errors, f.Error)
+	f.Error = f.Errors[0]
+}
+
+// resetErrors clears the field's errors
+func resetErrors(f *Field) {
+	f.Error = Error{}
+	f.Errors = nil
+}
+
+// bail reports whether a field set to bail on its first error has an error
+func bail(f *Field) bool {
+	return f.BailOnFirstError && f.Error.Type != ""
 }
 
 // GetFormError access a single form error value
@@ -64,6 +300,45 @@ func GetFormError(name string, c *Config) Error {
 	return err
 }
 
+// GetLabel access the label of a single form field, the field's Name if it has no Label
+//
+//	label := GetLabel("confirm_password", &c)
+func GetLabel(name string, c *Config) string {
+	var label string
+	for _, v := range c.Fields {
+		if v.Name == name {
+			label = v.label()
+		}
+	}
+	return label
+}
+
+// GetLabels access the labels of every form field as a map indexed off the form names,
+// whether or not the field has errors. Passed to a template as data, the `<label>` text
+// comes from the same source as the error messages:
+//
+//	<label for="title">{{ .Labels.title }}</label>
+func GetLabels(c *Config) map[string]string {
+	labels := make(map[string]string, len(c.Fields))
+	for _, v := range c.Fields {
+		labels[v.Name] = v.label()
+	}
+	return labels
+}
+
+// GetFieldErrors access all the errors of a single form field in the order they occurred
+//
+//	errs := GetFieldErrors("name", &c)
+func GetFieldErrors(name string, c *Config) []Error {
+	var errs []Error
+	for _, v := range c.Fields {
+		if v.Name == name {
+			errs = v.Errors
+		}
+	}
+	return errs
+}
+
 // GetFormErrors access all form errors as a map (`FormErrors`) indexed off the form names
 //
 //	var formErrs = form_validator.FormErrors{}
@@ -79,14 +354,31 @@ func GetFormError(name string, c *Config) Error {
 //	     {{ end }}
 //
 // In this case `FormErrors.title.error` will produce an error message that
-// can be safely displayed to the user.
+// can be safely displayed to the user & `FormErrors.title.label` the field's
+// label. `FormErrors.title.error` is the field's first error, every error
+// message is also indexed by its position e.g. "error.1" & can be ranged over
+// with `Messages`:
+//
+//	{{ range .FormErrors.Messages "title" }}
+//		<div class="alert alert-danger" role="alert">{{ . }}</div>
+//	{{ end }}
 func GetFormErrors(c *Config, fe *FormErrors) {
 	for _, v := range c.Fields {
 		if v.Error.Type != "" {
 			(*fe)[v.Name] = map[string]string{
 				v.Name:  v.Name,
+				"label": v.label(),
 				"error": v.Error.Message,
 			}
+			for i, err := range v.Errors {
+				(*fe)[v.Name][fmt.Sprintf("error.%d", i)] = err.Message
+			}
+			// The errors of multi value fields are also indexed by the value's position
+			for i, itemErr := range v.ItemErrors {
+				if itemErr.Type != "" {
+					(*fe)[v.Name][strconv.Itoa(i)] = itemErr.Message
+				}
+			}
 		}
 	}
 }
@@ -0,0 +1,65 @@
+package form_validator
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReturnsValidationError(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:      "username",
+				Validate:  true,
+				Type:      "string",
+				MinLength: 5,
+			},
+			{
+				Name:     "nickname",
+				Validate: true,
+				Type:     "string",
+				Validators: []ValidatorFunc{func(value interface{}, form *Result) *Error {
+					return &Error{Type: "ERROR_RESERVED", Message: "This nickname is reserved"}
+				}},
+			},
+		},
+	}
+	data := url.Values{}
+	data.Set("username", "joe")
+	data.Set("nickname", "admin")
+
+	err := Validate(newFormRequest(data), &c)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMissingValue))
+	assert.True(t, errors.Is(err, ErrTooShort))
+	assert.False(t, errors.Is(err, ErrOutOfRange))
+	assert.Equal(t, "form_validator: invalid form: "+"Missing value for name field"+"; "+
+		"username field must be at least 5 characters"+"; This nickname is reserved", err.Error())
+
+	var ve *ValidationError
+	if assert.True(t, errors.As(err, &ve)) {
+		assert.Len(t, ve.Errors, 3)
+		assert.Equal(t, &FieldError{Name: "username", Type: ERROR_TOO_SHORT, Message: "username field must be at least 5 characters"}, ve.Errors[1])
+		assert.Nil(t, ve.Errors[2].Unwrap())
+	}
+	var fe *FieldError
+	if assert.True(t, errors.As(err, &fe)) {
+		assert.Equal(t, "name", fe.Name)
+	}
+	// The results are written back to the config
+	assert.Equal(t, ERROR_MISSING_VALUE, GetFormError("name", &c).Type)
+
+	data.Set("name", "Joe")
+	data.Set("username", "joegasewicz")
+	c.Fields[2].Validators = nil
+	assert.NoError(t, Validate(newFormRequest(data), &c))
+	assert.NoError(t, NewSchema(&c).Validate(newFormRequest(data)).Err())
+}
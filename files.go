@@ -0,0 +1,174 @@
+package form_validator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers
+const sniffLen = 512
+
+// validateFile sets the field's Value to the first uploaded file of its name & checks the
+// file's size, extension & content type, as well as the dimensions of an "image" Type.
+// The content type is sniffed from the file's content rather than trusting the Content-Type
+// sent by the client
+func validateFile(f *Field, files map[string][]*multipart.FileHeader) {
+	fhs := files[f.Name]
+	if len(fhs) == 0 {
+		return
+	}
+	checkFile(f, fhs[0])
+}
+
+// checkFile sets the field's Value to the file & checks the file
+func checkFile(f *Field, fh *multipart.FileHeader) {
+	f.Initial = fh.Filename
+	f.Value = fh
+
+	if f.MaxFileSize > 0 && fh.Size > f.MaxFileSize {
+		addError(f, ERROR_FILE_TOO_LARGE)
+	}
+	if len(f.AllowedExtensions) > 0 && !bail(f) && !allowedExtension(fh.Filename, f.AllowedExtensions) {
+		addError(f, ERROR_FILE_EXTENSION)
+	}
+	if !checksContent(f) || bail(f) {
+		return
+	}
+	// The file is always closed
+	file, err := fh.Open()
+	if err != nil {
+		log.Println(err.Error())
+		addError(f, ERROR_FILE_TYPE)
+		return
+	}
+	defer file.Close()
+	checkContent(f, bufio.NewReaderSize(file, sniffLen))
+}
+
+// checksContent reports whether the field's constraints need the content of the file
+func checksContent(f *Field) bool {
+	return len(f.AllowedMIMETypes) > 0 || f.Type == "image"
+}
+
+// checkContent checks the content type sniffed from the first 512 bytes of the content, as well as
+// the dimensions of an "image" Type. Only as much of the content is read as the checks need, the
+// returned reader reads the whole content from its start
+func checkContent(f *Field, content *bufio.Reader) io.Reader {
+	if len(f.AllowedMIMETypes) > 0 {
+		head, err := content.Peek(sniffLen)
+		if err != nil && err != io.EOF {
+			log.Println(err.Error())
+			addError(f, ERROR_FILE_TYPE)
+			return content
+		}
+		if !allowedMIMEType(http.DetectContentType(head), f.AllowedMIMETypes) {
+			addError(f, ERROR_FILE_MIME_TYPE)
+		}
+	}
+	if f.Type != "image" || bail(f) {
+		return content
+	}
+	var head bytes.Buffer
+	checkImage(f, io.TeeReader(content, &head))
+	return io.MultiReader(&head, content)
+}
+
+// allowedExtension reports whether the filename has one of the extensions, which are
+// compared case-insensitively with or without their leading dot
+func allowedExtension(filename string, extensions []string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, allowed := range extensions {
+		if ext != "" && ext == "."+strings.TrimPrefix(strings.ToLower(allowed), ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedMIMEType reports whether the sniffed media type is one of the allowed types.
+// An allowed type may be a wildcard such as "image/*"
+func allowedMIMEType(mimeType string, allowed []string) bool {
+	mediaType, _, _ := strings.Cut(mimeType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	for _, t := range allowed {
+		t = strings.ToLower(t)
+		if t == mediaType || (strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(t, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatBytes formats a number of bytes in the largest unit it is at least 1 of, e.g. 2MB
+func formatBytes(n int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	size := float64(n)
+	i := 0
+	for ; size >= 1024 && i < len(units)-1; i++ {
+		size /= 1024
+	}
+	return strings.TrimSuffix(strings.TrimRight(strconv.FormatFloat(size, 'f', 1, 64), "0"), ".") + units[i]
+}
+
+// validateFiles validates every uploaded file of a "[]file" or "[]image" Type individually as
+// the slice's element type. The field's Value is set to the []*multipart.FileHeader of the files
+// & `ItemErrors` holds the first error of each file. The errors of the files are added to the
+// field's errors after any error on the number or total size of the files
+func validateFiles(f *Field, files map[string][]*multipart.FileHeader) {
+	fhs := files[f.Name]
+	if len(fhs) == 0 {
+		return
+	}
+	f.Value = fhs
+	f.Initials = make([]string, len(fhs))
+	f.ItemErrors = make([]Error, len(fhs))
+
+	var total int64
+	var itemErrs []Error
+	for i, fh := range fhs {
+		f.Initials[i] = fh.Filename
+		total += fh.Size
+		item := fileItem(f, i)
+		checkFile(&item, fh)
+		f.ItemErrors[i] = item.Error
+		itemErrs = append(itemErrs, item.Errors...)
+	}
+	f.Initial = strings.Join(f.Initials, ",")
+
+	switch n := len(fhs); {
+	case n < f.MinFiles:
+		addError(f, ERROR_TOO_FEW_FILES)
+	case f.MaxFiles > 0 && n > f.MaxFiles:
+		addError(f, ERROR_TOO_MANY_FILES)
+	}
+	if f.MaxTotalFileSize > 0 && total > f.MaxTotalFileSize && !bail(f) {
+		addError(f, ERROR_FILES_TOO_LARGE)
+	}
+	for _, err := range itemErrs {
+		if bail(f) {
+			return
+		}
+		appendError(f, err)
+	}
+}
+
+// fileItem returns a copy of a "[]file" or "[]image" field to validate its i'th file as the slice's element type
+func fileItem(f *Field, i int) Field {
+	item := *f
+	item.Name = fmt.Sprintf("%s[%d]", f.Name, i)
+	item.Label = fmt.Sprintf("%s[%d]", f.label(), i)
+	item.Type = strings.TrimPrefix(f.Type, "[]")
+	item.Value = nil
+	item.Initials = nil
+	item.ItemErrors = nil
+	resetErrors(&item)
+	return item
+}
@@ -0,0 +1,252 @@
+package form_validator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+type testFile struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+func newMultipartRequest(data url.Values, files ...testFile) *http.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for key, values := range data {
+		for _, v := range values {
+			mw.WriteField(key, v)
+		}
+	}
+	for _, f := range files {
+		fw, _ := mw.CreateFormFile(f.field, f.filename)
+		fw.Write(f.content)
+	}
+	mw.Close()
+	r := httptest.NewRequest("POST", "/upload", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestValidateFile(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:              "avatar",
+				Validate:          true,
+				Type:              "file",
+				MaxFileSize:       1024,
+				AllowedExtensions: []string{"png", ".JPG"},
+				AllowedMIMETypes:  []string{"image/png", "image/jpeg"},
+			},
+		},
+	})
+	res := s.Validate(newMultipartRequest(nil, testFile{"avatar", "me.PNG", pngHeader}))
+	assert.True(t, res.Valid(), res.Errors())
+	fh, err := GetFile("avatar", res.Config())
+	assert.NoError(t, err)
+	assert.Equal(t, "me.PNG", fh.Filename)
+	f, err := fh.Open()
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(f)
+	f.Close()
+	assert.Equal(t, pngHeader, b)
+
+	res = s.Validate(newMultipartRequest(nil))
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("avatar").Type)
+	_, err = GetFile("avatar", res.Config())
+	assert.ErrorIs(t, err, http.ErrMissingFile)
+}
+
+func TestFileErrors(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:              "avatar",
+				Validate:          true,
+				Type:              "file",
+				MaxFileSize:       1024,
+				AllowedExtensions: []string{"png", ".JPG"},
+				AllowedMIMETypes:  []string{"image/png", "image/jpeg"},
+			},
+		},
+	})
+
+	res := s.Validate(newMultipartRequest(nil, testFile{"avatar", "me.png", append(pngHeader, make([]byte, 1024)...)}))
+	assert.Equal(t, []Error{{Type: ERROR_FILE_TOO_LARGE, Message: "avatar file must be at most 1KB"}}, res.Config().Fields[0].Errors)
+
+	res = s.Validate(newMultipartRequest(nil, testFile{"avatar", "me.gif", pngHeader}))
+	assert.Equal(t, ERROR_FILE_EXTENSION, res.Error("avatar").Type)
+	assert.Equal(t, "avatar file must have one of the extensions png, .JPG", res.Error("avatar").Message)
+
+	// The client's filename & Content-Type aren't trusted, the content is sniffed
+	res = s.Validate(newMultipartRequest(nil, testFile{"avatar", "me.png", []byte("<html><script>alert(1)</script></html>")}))
+	assert.Equal(t, ERROR_FILE_MIME_TYPE, res.Error("avatar").Type)
+	assert.Equal(t, "avatar file must be of type image/png, image/jpeg", res.Error("avatar").Message)
+	assert.True(t, errors.Is(res.Err(), ErrFileMIMEType))
+}
+
+func TestUnreadableFile(t *testing.T) {
+	unreadable := bufio.NewReader(iotest.ErrReader(errors.New("disk error")))
+	f := Field{Name: "avatar", Type: "file", AllowedMIMETypes: []string{"image/png"}}
+	checkContent(&f, unreadable)
+	assert.Equal(t, Error{Type: ERROR_FILE_TYPE, Message: "avatar file could not be read"}, f.Error)
+
+	f = Field{Name: "avatar", Type: "file", AllowedMIMETypes: []string{"image/png"}}
+	f.localizer = newLocalizer(context.Background(), "de", NewCatalogs())
+	checkContent(&f, unreadable)
+	assert.Equal(t, "Die Datei avatar konnte nicht gelesen werden", f.Error.Message)
+
+	f.Messages = map[string]string{ERROR_FILE_TYPE: "{{.Label}} is corrupt"}
+	f.messages = compileMessages(f.Messages)
+	resetErrors(&f)
+	checkContent(&f, unreadable)
+	assert.Equal(t, "avatar is corrupt", f.Error.Message)
+}
+
+func TestFileMIMETypeWildcard(t *testing.T) {
+	assert.True(t, allowedMIMEType("image/png", []string{"image/*"}))
+	assert.True(t, allowedMIMEType("text/plain; charset=utf-8", []string{"text/plain"}))
+	assert.False(t, allowedMIMEType("application/pdf", []string{"image/*"}))
+	assert.False(t, allowedExtension("png", []string{"png"}))
+	assert.Equal(t, "1.5MB", formatBytes(1536*1024))
+	assert.Equal(t, "512B", formatBytes(512))
+}
+
+func TestBindFile(t *testing.T) {
+	var s struct {
+		Title  string                `form:"title"`
+		Avatar *multipart.FileHeader `form:"avatar" validate:"required"`
+	}
+	data := url.Values{}
+	data.Set("title", "Me")
+	formErrs, err := Bind(newMultipartRequest(data, testFile{"avatar", "me.png", pngHeader}), &s)
+	assert.NoError(t, err)
+	assert.Nil(t, formErrs)
+	assert.Equal(t, "Me", s.Title)
+	if assert.NotNil(t, s.Avatar) {
+		assert.Equal(t, "me.png", s.Avatar.Filename)
+	}
+}
+
+func TestBindResult(t *testing.T) {
+	var s struct {
+		Title  string                `form:"title" validate:"required"`
+		Avatar *multipart.FileHeader `form:"avatar" validate:"required"`
+	}
+	data := url.Values{}
+	data.Set("title", "Me")
+	r := newMultipartRequest(data, testFile{"avatar", "me.png", pngHeader})
+	res, err := BindResult(r, &s)
+	assert.NoError(t, err)
+	assert.True(t, res.Valid(), res.Errors())
+	assert.Equal(t, "Me", s.Title)
+	assert.Same(t, r.MultipartForm.File["avatar"][0], s.Avatar)
+	assert.NoError(t, res.Close())
+
+	res, err = BindResult(newMultipartRequest(data), &s)
+	assert.NoError(t, err)
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("avatar").Type)
+
+	_, err = BindResult(r, s)
+	assert.Error(t, err)
+}
+
+func TestValidateFiles(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:              "attachments",
+				Validate:          true,
+				Type:              "[]file",
+				MinFiles:          1,
+				MaxFiles:          3,
+				MaxFileSize:       1024,
+				MaxTotalFileSize:  2048,
+				AllowedExtensions: []string{"png"},
+			},
+		},
+	})
+	res := s.Validate(newMultipartRequest(nil,
+		testFile{"attachments", "a.png", pngHeader},
+		testFile{"attachments", "b.png", pngHeader},
+	))
+	assert.True(t, res.Valid())
+	fhs := GetFiles("attachments", res.Config())
+	if assert.Len(t, fhs, 2) {
+		assert.Equal(t, "a.png", fhs[0].Filename)
+		assert.Equal(t, "b.png", fhs[1].Filename)
+	}
+	assert.Nil(t, GetFiles("missing", res.Config()))
+
+	res = s.Validate(newMultipartRequest(nil))
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("attachments").Type)
+}
+
+func TestFilesErrors(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:              "attachments",
+				Validate:          true,
+				Type:              "[]file",
+				MinFiles:          1,
+				MaxFiles:          3,
+				MaxFileSize:       1024,
+				MaxTotalFileSize:  2048,
+				AllowedExtensions: []string{"png"},
+			},
+		},
+	})
+	res := s.Validate(newMultipartRequest(nil,
+		testFile{"attachments", "a.png", pngHeader},
+		testFile{"attachments", "b.gif", pngHeader},
+	))
+	assert.False(t, res.Valid())
+	assert.Equal(t, ERROR_FILE_EXTENSION, res.Error("attachments").Type)
+	formErrs := res.Errors()
+	assert.NotContains(t, formErrs["attachments"], "0")
+	assert.Equal(t, "attachments[1] file must have one of the extensions png", formErrs["attachments"]["1"])
+
+	f := testFile{"attachments", "a.png", pngHeader}
+	res = s.Validate(newMultipartRequest(nil, f, f, f, f))
+	assert.Equal(t, []string{ERROR_TOO_MANY_FILES}, errorTypes(res.Config().Fields[0].Errors))
+	assert.Equal(t, "attachments field must have at most 3 files", res.Error("attachments").Message)
+
+	big := testFile{"attachments", "a.png", append(pngHeader, make([]byte, 1000)...)}
+	res = s.Validate(newMultipartRequest(nil, big, big, big))
+	assert.Equal(t, []string{ERROR_FILES_TOO_LARGE}, errorTypes(res.Config().Fields[0].Errors))
+	assert.Equal(t, "attachments files must be at most 2KB in total", res.Error("attachments").Message)
+
+	s = NewSchema(&Config{Fields: []Field{{Name: "attachments", Type: "[]file", MinFiles: 2}}})
+	res = s.Validate(newMultipartRequest(nil, f))
+	assert.Equal(t, ERROR_TOO_FEW_FILES, res.Error("attachments").Type)
+	assert.True(t, errors.Is(res.Err(), ErrTooFewFiles))
+}
+
+func TestBindFiles(t *testing.T) {
+	var s struct {
+		Attachments []*multipart.FileHeader `form:"attachments"`
+	}
+	formErrs, err := Bind(newMultipartRequest(nil,
+		testFile{"attachments", "a.png", pngHeader},
+		testFile{"attachments", "b.png", pngHeader},
+	), &s)
+	assert.NoError(t, err)
+	assert.Nil(t, formErrs)
+	assert.Len(t, s.Attachments, 2)
+}
@@ -1,10 +1,16 @@
 package form_validator
 
 import (
+	"context"
+	"errors"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strconv"
-	"strings"
+	"text/template"
+	"time"
 )
 
 // Config the `Fields` struct field is where the form values are declared
@@ -30,24 +36,126 @@ import (
 //		The types are as followed
 //
 // - Name field is the form's 'name' value
+// - Label is the human-readable name of the field used in error messages, defaults to the Name
+// - Source reads the value from only the query string, body, a header, a cookie or a path value,
+// e.g. SOURCE_BODY. Fields without a Source are read from the form, which merges the query string & body
 // - Validate sets whether the field requires validation
 // - Default set a default value is the form field empty
 // - Type sets the type conversion e.g. int8, uint, float16 ...
+// - MinLength & MaxLength bound the length of a string value in characters (runes)
+// - Length sets an exact length of a string value in characters (runes)
+// - Min & Max bound the value of numeric types, they are parsed as the field's Type
+// - ExclusiveMin & ExclusiveMax exclude the Min & Max values themselves
+// - Pattern is a regular expression the whole submitted value must match
+// - PatternHint describes the Pattern in the error message e.g. "must contain only lowercase letters"
+// - AllowedSchemes restricts the schemes of a "url" Type e.g. []string{"https"}
+// - Layout overrides the time.Parse layout of the date & time types
+// - Location sets the *time.Location date & time values are parsed in, defaults to UTC
+// - MinDate & MaxDate bound the value of date & time types, they are parsed like the value
+// - Choices restricts the value to the values of a select or radio input's options
+// - MinItems & MaxItems bound the number of values of a slice Type e.g. []string, []int
+// - Validators are custom validation functions run after the built-in validation
+// - AsyncValidators are custom validation functions that receive a context, e.g. for database checks
+// - BailOnFirstError stops validating the field after its first error, otherwise all errors are collected
+// - MaxFileSize limits the size of a "file" Type in bytes
+// - AllowedExtensions restricts the extension of a "file" Type e.g. []string{".png", ".jpg"}
+// - AllowedMIMETypes restricts the content type of a "file" Type, which is sniffed from the file's content
+// e.g. []string{"image/png", "image/*"}
+// - MinWidth, MaxWidth, MinHeight & MaxHeight bound the dimensions of an "image" Type in pixels
+// - AspectRatio sets the width to height ratio of an "image" Type e.g. "16:9" or "1.5", within the
+// relative AspectRatioTolerance e.g. 0.01 for 1%
+// - MaxPixels limits the width times height of an "image" Type, to defend against decompression bombs
+// - MinFiles & MaxFiles bound the number of files of a "[]file" or "[]image" Type & MaxTotalFileSize
+// limits their total size in bytes
+// - Messages overrides the error messages of the field, keyed by error type e.g. ERROR_TOO_SHORT
+//
+// The Config's MaxConcurrency limits the number of fields whose AsyncValidators run at once &
+// AsyncTimeout limits the duration of each async validator, both are unlimited if 0.
+// The Config's Translator translates the error messages into the request's locale, see `NewCatalogs`
+// & the Config's Messages replace the built-in English error messages of all the fields.
+// ProblemStatus sets the status code of `WriteProblem` responses, it defaults to 400 &
+// MaxBodySize limits the size of JSON request bodies & the values of streamed multipart forms, it defaults to 10MB.
+// TempDir is the directory `ValidateStream` spools uploaded files to if it isn't given a `FileSink`,
+// it defaults to os.TempDir(). `Validate`, `ValidateMultiPartForm` & `Schema.Validate` panic if TempDir
+// is set, as r.ParseMultipartForm always writes to os.TempDir()
 type Config struct {
-	MaxMemory int64
-	Fields    []Field
+	MaxMemory      int64
+	MaxBodySize    int64
+	TempDir        string
+	MaxConcurrency int
+	AsyncTimeout   time.Duration
+	Translator     Translator
+	Messages       map[string]string
+	ProblemStatus  int
+	Fields         []Field
+	// bind applies the defaults of unvalidated fields for `Bind`
+	bind bool
+	// bodyErr is the error of a request body that couldn't be decoded or read
+	bodyErr error
 }
 
 // Field represents a form field
 type Field struct {
-	Name     string
-	Validate bool
-	Default  string
-	Type     string
-	Value    interface{}
-	Initial  string
-	Error    Error
-	Matches  string
+	Name                 string
+	Label                string
+	Source               string
+	Validate             bool
+	Default              string
+	Type                 string
+	Value                interface{}
+	Initial              string
+	Error                Error
+	Matches              string
+	MinLength            int
+	MaxLength            int
+	Length               int
+	Min                  string
+	Max                  string
+	ExclusiveMin         bool
+	ExclusiveMax         bool
+	Pattern              string
+	PatternHint          string
+	AllowedSchemes       []string
+	Layout               string
+	Location             *time.Location
+	MinDate              string
+	MaxDate              string
+	Choices              []Choice
+	MinItems             int
+	MaxItems             int
+	Initials             []string
+	ItemErrors           []Error
+	Errors               []Error
+	BailOnFirstError     bool
+	MaxFileSize          int64
+	AllowedExtensions    []string
+	AllowedMIMETypes     []string
+	MinWidth             int
+	MaxWidth             int
+	MinHeight            int
+	MaxHeight            int
+	AspectRatio          string
+	AspectRatioTolerance float64
+	MaxPixels            int64
+	MinFiles             int
+	MaxFiles             int
+	MaxTotalFileSize     int64
+	imageInfo            *ImageInfo
+	Validators           []ValidatorFunc
+	AsyncValidators      []AsyncValidatorFunc
+	Messages             map[string]string
+	messages             map[string]*template.Template
+	configMessages       map[string]*template.Template
+	localizer            *localizer
+	matchesLabel         string
+}
+
+// label returns the field's Label or its Name if it has no Label
+func (f *Field) label() string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Name
 }
 
 // Error object holds the error type & a message to display to the user
@@ -56,7 +164,9 @@ type Error struct {
 	Type    string
 }
 
-// ValidateForm validates a form
+// ValidateForm validates a form, or a JSON body if the request's Content-Type is application/json.
+// The field values & errors are written back to `c`, so a `Config` must not be shared between
+// concurrent requests, use a `Schema` instead
 //
 //	if ok := form_validator.ValidateForm(r, &c); ok {
 //		// form is valid
@@ -64,20 +174,35 @@ type Error struct {
 //		// form is invalid
 //	}
 func ValidateForm(r *http.Request, c *Config) bool {
+	return ValidateFormContext(r.Context(), r, c)
+}
+
+// ValidateFormContext validates a form like `ValidateForm`, passing ctx to the
+// fields' async validators
+//
+//	ctx, cancel := context.WithTimeout(r.Context(), time.Second)
+//	defer cancel()
+//	if ok := form_validator.ValidateFormContext(ctx, r, &c); ok {
+//		// form is valid
+//	}
+func ValidateFormContext(ctx context.Context, r *http.Request, c *Config) bool {
 	for _, f := range c.Fields {
-		if f.Type == "file" {
+		if isFile(&f) {
 			panic("You must use ValidateMultiPartForm function to parse MultiPartForm data")
 		}
 	}
+	if isJSON(r) {
+		return NewSchema(c).validateJSON(ctx, r).apply(c)
+	}
 	err := r.ParseForm()
 	if err != nil {
 		log.Println(err.Error())
 	}
-	validate(r, c)
-	return isFormValid(c)
+	return NewSchema(c).validate(ctx, r, r.Form, r.PostForm).apply(c)
 }
 
-// ValidateMultiPartForm validates a multipart form
+// ValidateMultiPartForm validates a multipart form. Like `ValidateForm` the results are
+// written back to `c`
 //
 //	if ok := form_validator.ValidateMultiPartForm(r, &c); ok {
 //		// form is valid
@@ -85,9 +210,35 @@ func ValidateForm(r *http.Request, c *Config) bool {
 //		// form is invalid
 //	}
 func ValidateMultiPartForm(r *http.Request, c *Config) bool {
+	checkTempDir(c.TempDir)
 	r.ParseMultipartForm(c.MaxMemory)
-	validate(r, c)
-	return isFormValid(c)
+	return NewSchema(c).validate(r.Context(), r, r.Form, r.PostForm).apply(c)
+}
+
+// Validate validates a form, multipart form or JSON body like `ValidateForm` & `ValidateMultiPartForm`,
+// returning a `*ValidationError` if the form is invalid. The results are written back to `c`
+//
+//	if err := form_validator.Validate(r, &c); errors.Is(err, form_validator.ErrMissingValue) {
+//		// a field is missing
+//	} else if err != nil {
+//		// form is invalid
+//	}
+func Validate(r *http.Request, c *Config) error {
+	res := NewSchema(c).ValidateContext(r.Context(), r)
+	res.apply(c)
+	return res.Err()
+}
+
+// ValidateValues validates values & files that have been parsed elsewhere, such as a query string,
+// CLI input or a stored draft. It is the core the other Validate functions delegate to. Like
+// `ValidateForm` the results are written back to `c`
+//
+//	values, _ := url.ParseQuery("name=Joe&age=42")
+//	if ok := form_validator.ValidateValues(values, nil, &c); ok {
+//		// values are valid
+//	}
+func ValidateValues(values url.Values, files map[string][]*multipart.FileHeader, c *Config) bool {
+	return NewSchema(c).ValidateValues(context.Background(), values, files).apply(c)
 }
 
 func isFormValid(c *Config) bool {
@@ -111,7 +262,28 @@ func setValueToInitialOrDefault(f *Field) string {
 	return ""
 }
 
+// setConversionError logs a failed conversion & flags the field as the incorrect type.
+// Values that overflow the type are flagged as out of range
+func setConversionError(f *Field, value string, err error) {
+	log.Printf("Error converting value of %s to type %s\n", value, f.Type)
+	if errors.Is(err, strconv.ErrRange) {
+		addError(f, ERROR_OUT_OF_RANGE)
+		return
+	}
+	addError(f, ERROR_INCORRECT_TYPE)
+}
+
+// convertToType converts the field's value to its Type. A value that fails conversion leaves
+// the field's Value nil, so the zero or clamped value returned by strconv isn't mistaken for it
 func convertToType(f *Field) {
+	errs := len(f.Errors)
+	convertValue(f)
+	if len(f.Errors) > errs {
+		f.Value = nil
+	}
+}
+
+func convertValue(f *Field) {
 	switch f.Type {
 	case "string":
 		f.Value = setValueToInitialOrDefault(f)
@@ -120,110 +292,99 @@ func convertToType(f *Field) {
 		initialOrDefault := setValueToInitialOrDefault(f)
 		b, err := strconv.ParseBool(initialOrDefault)
 		if err != nil {
-			log.Printf("Error converting value of %s to type bool\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = b
 	case "float32":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		float, err := strconv.ParseFloat(initialOrDefault, 32)
 		if err != nil {
-			log.Printf("Error converting value of %s to type float32\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
-		} else {
-			f.Value = float32(float)
+			setConversionError(f, initialOrDefault, err)
 		}
+		f.Value = float32(float)
 		break
 	case "float64":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		float, err := strconv.ParseFloat(initialOrDefault, 64)
 		if err != nil {
-			log.Printf("Error converting value of %s to type float64\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
-		} else {
-			f.Value = float // float64
+			setConversionError(f, initialOrDefault, err)
 		}
+		f.Value = float // float64
 		break
 	case "int":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.Atoi(initialOrDefault)
 		if err != nil {
-			log.Printf("Error converting value of %s to type int\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = u
 	case "uint":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseUint(initialOrDefault, 10, 64)
 		if err != nil {
-			log.Printf("Error converting value of %s to type uint\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = uint(u)
 	case "uint8":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseUint(initialOrDefault, 10, 8)
 		if err != nil {
-			log.Printf("Error converting value of %s to type uint8\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = uint8(u)
 	case "uint16":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseUint(initialOrDefault, 10, 16)
 		if err != nil {
-			log.Printf("Error converting value of %s to type uint16\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = uint16(u)
 	case "uint32":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseUint(initialOrDefault, 10, 32)
 		if err != nil {
-			log.Printf("Error converting value of %s to type uint32\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = uint32(u)
 	case "uint64":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseUint(initialOrDefault, 10, 64)
 		if err != nil {
-			log.Printf("Error converting value of %s to type uint64\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = u // uint64
 	case "int8":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseInt(initialOrDefault, 10, 8)
 		if err != nil {
-			log.Printf("Error converting value of %s to type int8\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = int8(u)
 	case "int16":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseInt(initialOrDefault, 10, 16)
 		if err != nil {
-			log.Printf("Error converting value of %s to type int16\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = int16(u)
 	case "int32":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseInt(initialOrDefault, 10, 32)
 		if err != nil {
-			log.Printf("Error converting value of %s to type int32\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = int32(u)
 	case "int64":
 		initialOrDefault := setValueToInitialOrDefault(f)
 		u, err := strconv.ParseInt(initialOrDefault, 10, 64)
 		if err != nil {
-			log.Printf("Error converting value of %s to type int64\n", initialOrDefault)
-			f.Type = ERROR_INCORRECT_TYPE
+			setConversionError(f, initialOrDefault, err)
 		}
 		f.Value = u // int64
+	case "email", "url", "uuid", "ip", "ipv4", "ipv6", "cidr", "hostname":
+		convertFormat(f)
+	case "date", "datetime-local", "time", "month", "week":
+		convertDate(f)
 	}
 }
 
@@ -235,67 +396,92 @@ func setFieldByName(c *Config, match string, field *Field) {
 	}
 }
 
-func validate(r *http.Request, c *Config) {
-	var fileErr error
-	e := Error{
-		Message: "",
-		Type:    "",
-	}
-	for key, value := range r.Form {
-		val := strings.Join(value, "")
+func validate(values url.Values, files map[string][]*multipart.FileHeader, c *Config) {
+	for key, value := range values {
+		// A field holding a single value takes the first of a repeated key like r.FormValue
+		var val string
+		if len(value) > 0 {
+			val = value[0]
+		}
 		for i, f := range c.Fields {
-			if f.Name == key {
+			// File fields are validated from the uploaded files
+			if f.Name == key && !isFile(&f) {
+				// Checkbox groups & multi-selects submit the same key once per value
+				if isMultiValue(&f) {
+					convertMultiValue(&c.Fields[i], value)
+					continue
+				}
 				c.Fields[i].Initial = val
+				resetErrors(&c.Fields[i])
 				// Validate the field value
 				if f.Validate {
-					if val == "" || val == "<nil>" {
-						e.Type = ERROR_MISSING_VALUE
-					}
 					if f.Type != "" {
 						convertToType(&c.Fields[i])
-						// Handle file validation
-						if f.Type == "file" {
-							cf, _, fileErr := r.FormFile(f.Name)
-							if fileErr != nil {
-								c.Fields[i].Error.Type = ERROR_FILE_TYPE
-							}
-							if cf == nil {
-								c.Fields[i].Error.Type = ERROR_MISSING_VALUE
-							}
-						}
 					} else {
 						c.Fields[i].Value = val
 					}
-					// Test filed matches
-				} else {
+					// A missing value takes precedence over a failed type conversion
+					if val == "" || val == "<nil>" {
+						resetErrors(&c.Fields[i])
+						addError(&c.Fields[i], ERROR_MISSING_VALUE)
+					}
+				} else if f.Type != "" && (val != "" || c.bind && f.Default != "") {
+					// Submitted values of unvalidated fields are converted too so their constraints apply,
+					// Bind also converts the Default of an empty value to write it into the struct
+					convertToType(&c.Fields[i])
+				} else if f.Type == "" || f.Type == "string" {
 					// set the value for unvalidated fields
 					c.Fields[i].Value = val
+				} else {
+					// An empty value isn't converted, so it's left nil like a missing value rather than
+					// handing validators a string in place of the field's type
+					c.Fields[i].Value = nil
 				}
-				// Set Error Message
-				c.Fields[i].Error = e
-				// set the error message & pass in a fileErr which may or may not be nil
-				setErrorMessage(&c.Fields[i], fileErr)
 			}
 		}
 	}
 
+	for i, f := range c.Fields {
+		if isFile(&f) && isMultiValue(&f) {
+			validateFiles(&c.Fields[i], files)
+		} else if isFile(&f) {
+			validateFile(&c.Fields[i], files)
+		}
+	}
+
 	for i, f := range c.Fields {
 		// If the form field undeclared then set an error
-		if f.Validate && f.Value == nil {
-			e.Type = ERROR_MISSING_VALUE
-			c.Fields[i].Error = e
-			setErrorMessage(&c.Fields[i], fileErr)
+		if f.Validate && f.Value == nil && f.Error.Type == "" {
+			addError(&c.Fields[i], ERROR_MISSING_VALUE)
+		} else if c.bind && f.Value == nil && f.Default != "" && !isFile(&f) && !isMultiValue(&f) {
+			// Bind falls back to the default of unvalidated fields missing from the form
+			if f.Type != "" {
+				convertToType(&c.Fields[i])
+			} else {
+				c.Fields[i].Value = f.Default
+			}
+			f = c.Fields[i]
+		}
+		// Check the constraints of submitted values that converted successfully
+		if c.Fields[i].Error.Type == "" && c.Fields[i].Initial != "" {
+			checkConstraints(&c.Fields[i])
 		}
 		// All field values have been set on the config object - now perform matching validation
-		if f.Matches != "" {
+		if f.Matches != "" && !bail(&c.Fields[i]) {
 			var matchedField Field
 			// Set a temporary matchedField var with the matching field only for matching
 			setFieldByName(c, f.Matches, &matchedField)
-			if f.Value != matchedField.Value {
-				e.Type = ERROR_FIELDS_DO_NOT_MATCH
-				c.Fields[i].Error = e
-				setErrorMessage(&c.Fields[i], fileErr)
+			if !reflect.DeepEqual(f.Value, matchedField.Value) {
+				addError(&c.Fields[i], ERROR_FIELDS_DO_NOT_MATCH)
 			}
 		}
 	}
+
+	// Run the custom validators once every field has been converted & checked
+	form := &Result{config: *c}
+	for i, f := range c.Fields {
+		if len(f.Validators) > 0 && f.Value != nil && f.Error.Type == "" {
+			runValidators(&c.Fields[i], form)
+		}
+	}
 }
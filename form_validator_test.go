@@ -196,6 +196,79 @@ func TestFormFailsValueEmptyString(t *testing.T) {
 	})
 }
 
+func TestUnvalidatedFieldsAreConverted(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "age",
+				Validate: false,
+				Type:     "int",
+				Max:      "2",
+			},
+			{
+				Name:     "title",
+				Validate: false,
+				Default:  "Mr",
+				Type:     "string",
+			},
+		},
+	}
+
+	data := url.Values{}
+	data.Set("age", "abc")
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, ValidateForm(r, &c))
+		assert.Equal(t, ERROR_INCORRECT_TYPE, c.Fields[0].Error.Type)
+		assert.Nil(t, c.Fields[0].Value)
+		// The Default of an unvalidated field is only applied by Bind
+		assert.Nil(t, c.Fields[1].Value)
+	})
+
+	data.Set("age", "5")
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, ValidateForm(r, &c))
+		assert.Equal(t, ERROR_OUT_OF_RANGE, c.Fields[0].Error.Type)
+	})
+
+	data.Set("age", "2")
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, ValidateForm(r, &c))
+		assert.Equal(t, 2, c.Fields[0].Value)
+	})
+
+	// An empty value of an unvalidated field isn't converted & is left nil
+	data.Set("age", "")
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, ValidateForm(r, &c))
+		assert.Nil(t, c.Fields[0].Value)
+	})
+}
+
+func TestRepeatedKeysAreNotJoined(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "age",
+				Validate: true,
+				Type:     "uint8",
+			},
+			{
+				Name: "name",
+				Type: "string",
+			},
+		},
+	}
+
+	data := url.Values{"age": {"1", "2"}, "name": {"a", "b"}}
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, ValidateForm(r, &c))
+		assert.Equal(t, uint8(1), c.Fields[0].Value)
+		assert.Equal(t, "a", c.Fields[1].Value)
+	})
+}
+
 func TestAllTypeConversionSuccessful(t *testing.T) {
 	c := Config{
 		MaxMemory: 0,
@@ -300,7 +373,7 @@ func TestAllTypeConversionSuccessful(t *testing.T) {
 	data.Set("is_int8", "127")
 	data.Set("is_int16", "32767")
 	data.Set("is_int32", "2147483647")
-	data.Set("is_int64", "18446744073709551615")
+	data.Set("is_int64", "9223372036854775807")
 	data.Set("is_int", "100")
 	data.Set("is_uint", "255")
 
@@ -549,3 +622,130 @@ func TestMatchFields(t *testing.T) {
 		}
 	})
 }
+
+func TestMultipleErrorsPerField(t *testing.T) {
+	fields := []Field{
+		{
+			Name:     "password",
+			Validate: true,
+			Type:     "string",
+		},
+		{
+			Name:        "username",
+			Validate:    true,
+			Type:        "string",
+			MinLength:   5,
+			Pattern:     "[a-z]+",
+			PatternHint: "must contain only lowercase letters",
+		},
+		{
+			Name:     "confirm_password",
+			Validate: true,
+			Type:     "string",
+			Matches:  "password",
+		},
+	}
+	c := Config{Fields: fields}
+
+	data := url.Values{}
+	data.Set("password", "wizard")
+	data.Set("username", "Joe")
+	data.Set("confirm_password", "")
+
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, ValidateForm(r, &c))
+		assert.Equal(t, []Error{
+			{Type: ERROR_TOO_SHORT, Message: "username field must be at least 5 characters"},
+			{Type: ERROR_PATTERN_MISMATCH, Message: "username field must contain only lowercase letters"},
+		}, GetFieldErrors("username", &c))
+		assert.Equal(t, ERROR_TOO_SHORT, GetFormError("username", &c).Type)
+		// A match failure no longer replaces a missing value error
+		assert.Equal(t, []Error{
+			{Type: ERROR_MISSING_VALUE, Message: "Missing value for confirm_password field"},
+			{Type: ERROR_FIELDS_DO_NOT_MATCH, Message: "Fields confirm_password and password should match."},
+		}, GetFieldErrors("confirm_password", &c))
+
+		var formErrs = FormErrors{}
+		GetFormErrors(&c, &formErrs)
+		assert.Equal(t, "username field must be at least 5 characters", formErrs["username"]["error"])
+		assert.Equal(t, []string{
+			"username field must be at least 5 characters",
+			"username field must contain only lowercase letters",
+		}, formErrs.Messages("username"))
+		assert.Nil(t, formErrs.Messages("password"))
+	})
+
+	c.Fields[1].BailOnFirstError = true
+	c.Fields[2].BailOnFirstError = true
+	createFormRequest(data, func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, ValidateForm(r, &c))
+		assert.Equal(t, []Error{
+			{Type: ERROR_TOO_SHORT, Message: "username field must be at least 5 characters"},
+		}, GetFieldErrors("username", &c))
+		assert.Equal(t, []Error{
+			{Type: ERROR_MISSING_VALUE, Message: "Missing value for confirm_password field"},
+		}, GetFieldErrors("confirm_password", &c))
+	})
+}
+
+func TestLabelsInErrorMessages(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "password",
+				Label:    "Password",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "confirm_password",
+				Label:    "Confirm password",
+				Validate: true,
+				Type:     "string",
+				Matches:  "password",
+			},
+			{
+				Name:     "age",
+				Label:    "Age",
+				Validate: true,
+				Type:     "uint8",
+			},
+			{
+				Name:     "nickname",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:      "tags",
+				Label:     "Tags",
+				Validate:  true,
+				Type:      "[]string",
+				MaxLength: 3,
+			},
+		},
+	}
+	data := url.Values{}
+	data.Set("password", "wizard")
+	data.Set("confirm_password", "blizzard")
+	data.Set("age", "old")
+	data.Add("tags", "go")
+	data.Add("tags", "forms")
+
+	res := NewSchema(&c).Validate(newFormRequest(data))
+	formErrs := res.Errors()
+	assert.Equal(t, "Fields Confirm password and Password should match.", formErrs["confirm_password"]["error"])
+	assert.Equal(t, "Confirm password", formErrs["confirm_password"]["label"])
+	assert.Equal(t, "Expected a value of type uint8 for Age field", formErrs["age"]["error"])
+	assert.Equal(t, "Missing value for nickname field", formErrs["nickname"]["error"])
+	assert.Equal(t, "nickname", formErrs["nickname"]["label"])
+	assert.Equal(t, "Tags[1] field must be at most 3 characters", formErrs["tags"]["1"])
+	assert.Equal(t, "Password", res.Label("password"))
+	assert.Equal(t, "nickname", GetLabel("nickname", res.Config()))
+	assert.Equal(t, "", GetLabel("missing", res.Config()))
+	// Valid fields have labels too
+	assert.NotContains(t, formErrs, "password")
+	labels := res.Labels()
+	assert.Equal(t, "Password", labels["password"])
+	assert.Equal(t, "nickname", labels["nickname"])
+	assert.Len(t, GetLabels(res.Config()), 5)
+}
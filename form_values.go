@@ -2,7 +2,15 @@ package form_validator
 
 import (
 	"fmt"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
 	"strconv"
+	"time"
 )
 
 func getFormValue(name string, c *Config) interface{} {
@@ -20,6 +28,9 @@ func getFormValue(name string, c *Config) interface{} {
 // 		myStr, _ = GetString("name", &c)
 //
 func GetString(name string, c *Config) (string, error) {
+	if addr, ok := getFormValue(name, c).(mail.Address); ok {
+		return addr.Address, nil
+	}
 	return fmt.Sprintf("%v", getFormValue(name, c)), nil
 }
 
@@ -220,3 +231,170 @@ func GetUint64(name string, c *Config) (uint64, error) {
 	}
 	return u, err
 }
+
+// GetEmail gets email types from the form values
+//
+//
+// 		myEmail, _ = GetEmail("email", &c)
+//
+func GetEmail(name string, c *Config) (mail.Address, error) {
+	if addr, ok := getFormValue(name, c).(mail.Address); ok {
+		return addr, nil
+	}
+	addr, err := mail.ParseAddress(fmt.Sprintf("%v", getFormValue(name, c)))
+	if err != nil {
+		return mail.Address{}, err
+	}
+	return *addr, err
+}
+
+// GetURL gets url types from the form values
+//
+//
+// 		myURL, _ = GetURL("website", &c)
+//
+func GetURL(name string, c *Config) (*url.URL, error) {
+	if u, ok := getFormValue(name, c).(*url.URL); ok {
+		return u, nil
+	}
+	return url.Parse(fmt.Sprintf("%v", getFormValue(name, c)))
+}
+
+// GetIP gets ip, ipv4 & ipv6 types from the form values
+//
+//
+// 		myIP, _ = GetIP("ip", &c)
+//
+func GetIP(name string, c *Config) (net.IP, error) {
+	if ip, ok := getFormValue(name, c).(net.IP); ok && ip != nil {
+		return ip, nil
+	}
+	b := fmt.Sprintf("%v", getFormValue(name, c))
+	ip := net.ParseIP(b)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: b}
+	}
+	return ip, nil
+}
+
+// GetCIDR gets cidr types from the form values
+//
+//
+// 		myPrefix, _ = GetCIDR("subnet", &c)
+//
+func GetCIDR(name string, c *Config) (netip.Prefix, error) {
+	if prefix, ok := getFormValue(name, c).(netip.Prefix); ok && prefix.IsValid() {
+		return prefix, nil
+	}
+	return netip.ParsePrefix(fmt.Sprintf("%v", getFormValue(name, c)))
+}
+
+// GetTime gets date & time types from the form values
+//
+//
+// 		myTime, _ = GetTime("starts_at", &c)
+//
+func GetTime(name string, c *Config) (time.Time, error) {
+	if t, ok := getFormValue(name, c).(time.Time); ok {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, fmt.Sprintf("%v", getFormValue(name, c)))
+}
+
+// GetDate gets date & time types from the form values with the time set to midnight
+//
+//
+// 		myDate, _ = GetDate("birthday", &c)
+//
+func GetDate(name string, c *Config) (time.Time, error) {
+	t, ok := getFormValue(name, c).(time.Time)
+	if !ok {
+		var err error
+		t, err = time.Parse("2006-01-02", fmt.Sprintf("%v", getFormValue(name, c)))
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location()), nil
+}
+
+// GetStrings gets the values of multi value types from the form values
+//
+//
+// 		myStrings, _ = GetStrings("tags", &c)
+//
+func GetStrings(name string, c *Config) ([]string, error) {
+	value := getFormValue(name, c)
+	if strs, ok := value.([]string); ok {
+		return strs, nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a slice value for %s field", name)
+	}
+	strs := make([]string, v.Len())
+	for i := range strs {
+		strs[i] = fmt.Sprintf("%v", v.Index(i).Interface())
+	}
+	return strs, nil
+}
+
+// GetInts gets the values of multi value types from the form values
+//
+//
+// 		myInts, _ = GetInts("ids", &c)
+//
+func GetInts(name string, c *Config) ([]int, error) {
+	if ints, ok := getFormValue(name, c).([]int); ok {
+		return ints, nil
+	}
+	strs, err := GetStrings(name, c)
+	if err != nil {
+		return nil, err
+	}
+	ints := make([]int, len(strs))
+	for i, b := range strs {
+		ints[i], err = strconv.Atoi(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ints, nil
+}
+
+// GetFile gets the header of a file type from the form values, use its Open method to read the file
+//
+//
+// 		myFile, _ = GetFile("avatar", &c)
+//
+func GetFile(name string, c *Config) (*multipart.FileHeader, error) {
+	if fh, ok := getFormValue(name, c).(*multipart.FileHeader); ok {
+		return fh, nil
+	}
+	return nil, http.ErrMissingFile
+}
+
+// GetImageInfo gets the format & dimensions of an image type from the form values
+//
+//
+// 		myImage, _ = GetImageInfo("avatar", &c)
+//
+func GetImageInfo(name string, c *Config) (ImageInfo, error) {
+	for _, v := range c.Fields {
+		if v.Name == name && v.imageInfo != nil {
+			return *v.imageInfo, nil
+		}
+	}
+	return ImageInfo{}, fmt.Errorf("form_validator: no image for %s field", name)
+}
+
+// GetFiles gets the headers of the files of a []file or []image type from the form values
+//
+//
+// 		myFiles := GetFiles("attachments", &c)
+//
+func GetFiles(name string, c *Config) []*multipart.FileHeader {
+	fhs, _ := getFormValue(name, c).([]*multipart.FileHeader)
+	return fhs
+}
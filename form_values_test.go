@@ -1,6 +1,14 @@
 package form_validator
 
-import "testing"
+import (
+	"net"
+	"net/mail"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestGetString(t *testing.T) {
 	c := Config{
@@ -89,3 +97,60 @@ func TestGetBool(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGetFormats(t *testing.T) {
+	c := Config{
+		MaxMemory: 0,
+		Fields: []Field{
+			{Name: "email", Type: "email", Value: mail.Address{Address: "joe@email.com"}},
+			{Name: "website", Type: "url", Value: "https://example.com"},
+			{Name: "ip", Type: "ip", Value: net.ParseIP("127.0.0.1")},
+			{Name: "subnet", Type: "cidr", Value: "10.0.0.0/8"},
+		},
+	}
+
+	email, err := GetEmail("email", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "joe@email.com", email.Address)
+	str, _ := GetString("email", &c)
+	assert.Equal(t, "joe@email.com", str)
+
+	website, err := GetURL("website", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", website.Host)
+
+	ip, err := GetIP("ip", &c)
+	assert.NoError(t, err)
+	assert.True(t, ip.Equal(net.ParseIP("127.0.0.1")))
+	_, err = GetIP("website", &c)
+	assert.Error(t, err)
+
+	subnet, err := GetCIDR("subnet", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("10.0.0.0/8"), subnet)
+}
+
+func TestGetTimeAndDate(t *testing.T) {
+	c := Config{
+		MaxMemory: 0,
+		Fields: []Field{
+			{Name: "starts_at", Type: "datetime-local", Value: time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)},
+			{Name: "birthday", Type: "date", Value: "1990-05-17"},
+		},
+	}
+
+	startsAt, err := GetTime("starts_at", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC), startsAt)
+
+	startDate, err := GetDate("starts_at", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), startDate)
+
+	birthday, err := GetDate("birthday", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), birthday)
+
+	_, err = GetTime("birthday", &c)
+	assert.Error(t, err)
+}
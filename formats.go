@@ -0,0 +1,104 @@
+package form_validator
+
+import (
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// convertFormat converts the semantic string formats. The value is set to the parsed
+// Go type where one exists:
+//
+// - email: mail.Address
+// - url: *url.URL
+// - uuid: string in lowercase
+// - ip, ipv4 & ipv6: net.IP
+// - cidr: netip.Prefix
+// - hostname: string
+func convertFormat(f *Field) {
+	initialOrDefault := setValueToInitialOrDefault(f)
+	var ok bool
+	switch f.Type {
+	case "email":
+		var addr *mail.Address
+		addr, ok = parseEmail(initialOrDefault)
+		if ok {
+			f.Value = *addr
+		}
+	case "url":
+		var u *url.URL
+		u, ok = parseURL(initialOrDefault, f.AllowedSchemes)
+		if ok {
+			f.Value = u
+		}
+	case "uuid":
+		ok = uuidPattern.MatchString(initialOrDefault)
+		f.Value = strings.ToLower(initialOrDefault)
+	case "ip", "ipv4", "ipv6":
+		ip := net.ParseIP(initialOrDefault)
+		isV4 := !strings.Contains(initialOrDefault, ":")
+		ok = ip != nil && (f.Type == "ip" || (f.Type == "ipv4") == isV4)
+		f.Value = ip
+	case "cidr":
+		prefix, err := netip.ParsePrefix(initialOrDefault)
+		ok = err == nil
+		f.Value = prefix
+	case "hostname":
+		ok = isHostname(initialOrDefault)
+		f.Value = initialOrDefault
+	}
+	if !ok {
+		setConversionError(f, initialOrDefault, nil)
+	}
+}
+
+// parseEmail parses a single bare address such as "joe@email.com",
+// addresses with a display name e.g. "Joe <joe@email.com>" are rejected
+func parseEmail(s string) (*mail.Address, bool) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name != "" || addr.Address != s {
+		return nil, false
+	}
+	return addr, true
+}
+
+// parseURL parses an absolute URL with a host & a scheme from the allowed schemes
+func parseURL(s string, allowedSchemes []string) (*url.URL, bool) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, false
+	}
+	if len(allowedSchemes) == 0 {
+		return u, true
+	}
+	for _, scheme := range allowedSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return u, true
+		}
+	}
+	return nil, false
+}
+
+// isHostname reports whether s is a valid RFC 1123 hostname
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package form_validator
+
+import (
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatConversion(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		field     Field
+		value     string
+		wantValue interface{}
+		wantErr   string
+	}{
+		"email":                      {field: Field{Type: "email"}, value: "joe@email.com", wantValue: mail.Address{Address: "joe@email.com"}},
+		"email without domain":       {field: Field{Type: "email"}, value: "joe", wantErr: ERROR_INCORRECT_TYPE},
+		"email with display name":    {field: Field{Type: "email"}, value: "Joe <joe@email.com>", wantErr: ERROR_INCORRECT_TYPE},
+		"url":                        {field: Field{Type: "url"}, value: "ftp://example.com/file", wantValue: &url.URL{Scheme: "ftp", Host: "example.com", Path: "/file"}},
+		"url with allowed scheme":    {field: Field{Type: "url", AllowedSchemes: []string{"https"}}, value: "HTTPS://example.com", wantValue: &url.URL{Scheme: "https", Host: "example.com"}},
+		"url with disallowed scheme": {field: Field{Type: "url", AllowedSchemes: []string{"https"}}, value: "javascript://example.com", wantErr: ERROR_INCORRECT_TYPE},
+		"relative url":               {field: Field{Type: "url"}, value: "/path", wantErr: ERROR_INCORRECT_TYPE},
+		"uuid":                       {field: Field{Type: "uuid"}, value: "F47AC10B-58CC-4372-A567-0E02B2C3D479", wantValue: "f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+		"invalid uuid":               {field: Field{Type: "uuid"}, value: "f47ac10b58cc4372a5670e02b2c3d479", wantErr: ERROR_INCORRECT_TYPE},
+		"ip":                         {field: Field{Type: "ip"}, value: "::1", wantValue: net.ParseIP("::1")},
+		"ipv4":                       {field: Field{Type: "ipv4"}, value: "192.168.0.1", wantValue: net.ParseIP("192.168.0.1")},
+		"ipv4 given ipv6":            {field: Field{Type: "ipv4"}, value: "::ffff:192.168.0.1", wantErr: ERROR_INCORRECT_TYPE},
+		"ipv6":                       {field: Field{Type: "ipv6"}, value: "2001:db8::1", wantValue: net.ParseIP("2001:db8::1")},
+		"ipv6 given ipv4":            {field: Field{Type: "ipv6"}, value: "192.168.0.1", wantErr: ERROR_INCORRECT_TYPE},
+		"cidr":                       {field: Field{Type: "cidr"}, value: "10.0.0.0/8", wantValue: netip.MustParsePrefix("10.0.0.0/8")},
+		"invalid cidr":               {field: Field{Type: "cidr"}, value: "10.0.0.0", wantErr: ERROR_INCORRECT_TYPE},
+		"hostname":                   {field: Field{Type: "hostname"}, value: "api.example.com", wantValue: "api.example.com"},
+		"invalid hostname":           {field: Field{Type: "hostname"}, value: "-api.example.com", wantErr: ERROR_INCORRECT_TYPE},
+	}
+
+	for name, tt := range testcases {
+		name := name
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.field.Name = "value"
+			tt.field.Validate = true
+			data := url.Values{}
+			data.Set("value", tt.value)
+			res := NewSchema(&Config{Fields: []Field{tt.field}}).Validate(newFormRequest(data))
+			assert.Equal(t, tt.wantErr, res.Error("value").Type)
+			if tt.wantErr == "" {
+				assert.Equal(t, tt.wantValue, res.Value("value"))
+			} else {
+				assert.Equal(t, "Expected a value of type "+tt.field.Type+" for value field", res.Error("value").Message)
+			}
+		})
+	}
+}
+
+func TestUnvalidatedFormats(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{Name: "email", Type: "email"},
+			{Name: "website", Type: "url"},
+			{Name: "ip", Type: "ip"},
+			{Name: "token", Type: "uuid"},
+		},
+	}
+
+	data := url.Values{}
+	data.Set("email", "not an email")
+	data.Set("website", "example")
+	data.Set("ip", "localhost")
+	data.Set("token", "abc")
+	assert.False(t, ValidateValues(data, nil, &c))
+	for _, f := range c.Fields {
+		assert.Equal(t, ERROR_INCORRECT_TYPE, f.Error.Type, f.Name)
+	}
+
+	data.Set("email", "joe@email.com")
+	data.Set("website", "https://example.com")
+	data.Set("ip", "127.0.0.1")
+	data.Set("token", "F47AC10B-58CC-4372-A567-0E02B2C3D479")
+	assert.True(t, ValidateValues(data, nil, &c))
+	email, err := GetEmail("email", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "joe@email.com", email.Address)
+	website, err := GetURL("website", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", website.Host)
+	ip, err := GetIP("ip", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, net.ParseIP("127.0.0.1"), ip)
+	assert.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", c.Fields[3].Value)
+}
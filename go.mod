@@ -1,11 +1,14 @@
 module github.com/joegasewicz/form-validator
 
-go 1.18
+go 1.22
 
-require github.com/stretchr/testify v1.7.4
+require (
+	github.com/stretchr/testify v1.7.4
+	golang.org/x/image v0.18.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,239 @@
+package form_validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Translator translates the message of an error type into a locale. It returns false
+// if it has no message for the locale & error type, in which case the next preferred
+// locale is tried before falling back to the Config's Messages & the built-in English
+// messages. A Field's own Messages are never translated
+type Translator interface {
+	Translate(locale, errType string, params MessageParams) (string, bool)
+}
+
+// MessageParams are the parameters that can be interpolated into a message template,
+// e.g. "{{.Label}} must be at least {{.Min}} characters". Min & Max hold the bounds
+// relevant to the error type, e.g. the MinLength for an `ERROR_TOO_SHORT` error & Choices
+// the allowed values, e.g. the AllowedExtensions for an `ERROR_FILE_EXTENSION` error
+type MessageParams struct {
+	Name         string
+	Label        string
+	Type         string
+	Value        string
+	Min          string
+	Max          string
+	Length       int
+	Width        int
+	Height       int
+	Ratio        string
+	ExclusiveMin bool
+	ExclusiveMax bool
+	Date         bool
+	Matches      string
+	MatchesLabel string
+	Hint         string
+	Choices      string
+}
+
+// Catalog holds the message templates of a locale keyed by error type, e.g.
+//
+//	form_validator.Catalog{
+//		form_validator.ERROR_MISSING_VALUE: "{{.Label}} is required",
+//	}
+type Catalog map[string]string
+
+// Catalogs is a `Translator` that holds a `Catalog` per locale. Locales such as "de-AT"
+// fall back to their base language "de"
+//
+//	c := form_validator.Config{
+//		Translator: form_validator.NewCatalogs(),
+//		Fields:     fields,
+//	}
+type Catalogs struct {
+	mu        sync.RWMutex
+	templates map[string]map[string]*template.Template
+}
+
+// NewCatalogs returns `Catalogs` holding the bundled English (en), German (de),
+// French (fr) & Spanish (es) catalogs
+func NewCatalogs() *Catalogs {
+	cs := &Catalogs{templates: map[string]map[string]*template.Template{}}
+	for locale, catalog := range bundledCatalogs {
+		if err := cs.Add(locale, catalog); err != nil {
+			panic(err)
+		}
+	}
+	return cs
+}
+
+// Add compiles the catalog's templates & adds them to the locale, replacing
+// the locale's existing messages of the same error types
+func (cs *Catalogs) Add(locale string, catalog Catalog) error {
+	templates := make(map[string]*template.Template, len(catalog))
+	for errType, message := range catalog {
+		tmpl, err := template.New(errType).Parse(message)
+		if err != nil {
+			return fmt.Errorf("form_validator: invalid %s message for locale %s: %w", errType, locale, err)
+		}
+		templates[errType] = tmpl
+	}
+	locale = normalizeLocale(locale)
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.templates[locale] == nil {
+		cs.templates[locale] = map[string]*template.Template{}
+	}
+	for errType, tmpl := range templates {
+		cs.templates[locale][errType] = tmpl
+	}
+	return nil
+}
+
+// LoadFile adds a catalog from a JSON (.json) or YAML (.yaml, .yml) file of
+// error types & message templates to the locale
+//
+//	ERROR_MISSING_VALUE: "{{.Label}} ist ein Pflichtfeld"
+//	ERROR_TOO_SHORT: "{{.Label}} ist zu kurz"
+func (cs *Catalogs) LoadFile(locale, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var catalog Catalog
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(b, &catalog)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &catalog)
+	default:
+		return fmt.Errorf("form_validator: unsupported catalog file %s, expected .json, .yaml or .yml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("form_validator: parsing catalog file %s: %w", path, err)
+	}
+	return cs.Add(locale, catalog)
+}
+
+// Translate implements `Translator`
+func (cs *Catalogs) Translate(locale, errType string, params MessageParams) (string, bool) {
+	locale = normalizeLocale(locale)
+	cs.mu.RLock()
+	tmpl, ok := cs.templates[locale][errType]
+	if !ok {
+		if base, _, found := strings.Cut(locale, "-"); found {
+			tmpl, ok = cs.templates[base][errType]
+		}
+	}
+	cs.mu.RUnlock()
+	if !ok {
+		return "", false
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// isEnglish reports whether the locale is English, e.g. "en" or "en-GB"
+func isEnglish(locale string) bool {
+	base, _, _ := strings.Cut(normalizeLocale(locale), "-")
+	return base == "en"
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx that selects the locale of the error messages,
+// taking precedence over the request's Accept-Language header
+//
+//	r = r.WithContext(form_validator.WithLocale(r.Context(), "de"))
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// localizer holds the per request state used to translate the error messages of a field
+type localizer struct {
+	translator Translator
+	locales    []string
+}
+
+// newLocalizer returns the localizer of a request, the locales are those set with
+// `WithLocale` or else those of the Accept-Language header in order of preference
+func newLocalizer(ctx context.Context, acceptLanguage string, translator Translator) *localizer {
+	if translator == nil {
+		return nil
+	}
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale != "" {
+		return &localizer{translator: translator, locales: []string{locale}}
+	}
+	return &localizer{translator: translator, locales: acceptLanguages(acceptLanguage)}
+}
+
+// translate sets the field's error message in the first of the preferred locales
+// that has a message for the error type. The Config's Messages take precedence over
+// the translator's English messages as they replace the built-in English messages
+func (l *localizer) translate(f *Field) bool {
+	if l == nil {
+		return false
+	}
+	params := messageParams(f)
+	for _, locale := range l.locales {
+		if isEnglish(locale) && executeMessage(f, f.configMessages[f.Error.Type]) {
+			return true
+		}
+		if message, ok := l.translator.Translate(locale, f.Error.Type, params); ok {
+			f.Error.Message = message
+			return true
+		}
+	}
+	return false
+}
+
+// acceptLanguages parses an Accept-Language header into its locales ordered by quality
+func acceptLanguages(header string) []string {
+	type language struct {
+		locale  string
+		quality float64
+	}
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			if v, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64); err == nil {
+				quality = v
+			}
+		}
+		if quality > 0 {
+			languages = append(languages, language{locale, quality})
+		}
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+	locales := make([]string, len(languages))
+	for i, l := range languages {
+		locales[i] = l.locale
+	}
+	return locales
+}
@@ -0,0 +1,110 @@
+package form_validator
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMessages(t *testing.T) {
+	fields := []Field{
+		{Name: "a", Type: "string", Error: Error{Type: ERROR_MISSING_VALUE, Message: "Missing value for a field"}},
+		{Name: "a", Type: "uint8", Error: Error{Type: ERROR_INCORRECT_TYPE, Message: "Expected a value of type uint8 for a field"}},
+		{Name: "a", Matches: "b", Error: Error{Type: ERROR_FIELDS_DO_NOT_MATCH, Message: "Fields a and b should match."}},
+		{Name: "a", MinLength: 3, Error: Error{Type: ERROR_TOO_SHORT, Message: "a field must be at least 3 characters"}},
+		{Name: "a", Length: 3, Error: Error{Type: ERROR_TOO_LONG, Message: "a field must be exactly 3 characters"}},
+		{Name: "a", Type: "int", Min: "1", Max: "5", ExclusiveMax: true, Error: Error{Type: ERROR_OUT_OF_RANGE, Message: "a field must be at least 1 and less than 5"}},
+		{Name: "a", Type: "uint8", Initial: "300", Error: Error{Type: ERROR_OUT_OF_RANGE, Message: "a field must be at least 0 and at most 255"}},
+		{Name: "a", Type: "date", MinDate: "2024-01-01", Error: Error{Type: ERROR_OUT_OF_RANGE, Message: "a field must be on or after 2024-01-01"}},
+		{Name: "a", Type: "date", MinDate: "2024-01-01", MaxDate: "2024-12-31", Error: Error{Type: ERROR_OUT_OF_RANGE, Message: "a field must be between 2024-01-01 and 2024-12-31"}},
+		{Name: "a", PatternHint: "must be lowercase", Error: Error{Type: ERROR_PATTERN_MISMATCH, Message: "a field must be lowercase"}},
+		{Name: "a", Error: Error{Type: ERROR_PATTERN_MISMATCH, Message: "a field is not in the correct format"}},
+		{Name: "a", Choices: []Choice{{Value: "gb", Label: "United Kingdom"}, {Value: "fr"}}, Error: Error{Type: ERROR_INVALID_CHOICE, Message: "a field must be one of United Kingdom, fr"}},
+		{Name: "a", MinItems: 2, Error: Error{Type: ERROR_TOO_FEW_ITEMS, Message: "a field must have at least 2 values"}},
+		{Name: "a", MaxItems: 2, Error: Error{Type: ERROR_TOO_MANY_ITEMS, Message: "a field must have at most 2 values"}},
+		{Name: "a", Error: Error{Type: ERROR_CUSTOM, Message: "Invalid value for a field"}},
+		{Name: "a", Error: Error{Type: ERROR_TIMEOUT, Message: "Validation of a field timed out"}},
+		{Name: "a", Error: Error{Type: ERROR_CANCELED, Message: "Validation of a field was canceled"}},
+		{Name: "a", Error: Error{Type: ERROR_FILE_TYPE, Message: "a file could not be read"}},
+		{Name: "a", MaxFileSize: 2 << 20, Error: Error{Type: ERROR_FILE_TOO_LARGE, Message: "a file must be at most 2MB"}},
+		{Name: "a", AllowedExtensions: []string{".png", ".jpg"}, Error: Error{Type: ERROR_FILE_EXTENSION, Message: "a file must have one of the extensions .png, .jpg"}},
+		{Name: "a", AllowedMIMETypes: []string{"image/png"}, Error: Error{Type: ERROR_FILE_MIME_TYPE, Message: "a file must be of type image/png"}},
+		{Name: "a", MinWidth: 100, Error: Error{Type: ERROR_IMAGE_TOO_SMALL, Message: "a image must be at least 100 pixels wide"}},
+		{Name: "a", MaxWidth: 100, MaxHeight: 50, Error: Error{Type: ERROR_IMAGE_TOO_LARGE, Message: "a image must be at most 100 pixels wide and 50 pixels high"}},
+		{Name: "a", AspectRatio: "16:9", Error: Error{Type: ERROR_ASPECT_RATIO, Message: "a image must have an aspect ratio of 16:9"}},
+		{Name: "a", MaxPixels: 1000000, Error: Error{Type: ERROR_TOO_MANY_PIXELS, Message: "a image must have at most 1000000 pixels"}},
+		{Name: "a", MinFiles: 2, Error: Error{Type: ERROR_TOO_FEW_FILES, Message: "a field must have at least 2 files"}},
+		{Name: "a", MaxFiles: 2, Error: Error{Type: ERROR_TOO_MANY_FILES, Message: "a field must have at most 2 files"}},
+		{Name: "a", MaxTotalFileSize: 10 << 20, Error: Error{Type: ERROR_FILES_TOO_LARGE, Message: "a files must be at most 10MB in total"}},
+	}
+	for _, f := range fields {
+		want := f.Error.Message
+		f.Error.Message = ""
+		setErrorMessage(&f)
+		assert.Equal(t, want, f.Error.Message, f.Error.Type)
+	}
+}
+
+func TestTranslateErrorMessages(t *testing.T) {
+	s := NewSchema(&Config{
+		Translator: NewCatalogs(),
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:      "username",
+				Validate:  true,
+				Type:      "string",
+				MinLength: 3,
+			},
+		},
+	})
+	data := url.Values{}
+	data.Set("username", "jo")
+
+	r := newFormRequest(data)
+	r.Header.Set("Accept-Language", "nl;q=0.9, de-AT, en;q=0.8")
+	res := s.Validate(r)
+	assert.Equal(t, "Das Feld name ist ein Pflichtfeld", res.Error("name").Message)
+	assert.Equal(t, "Das Feld username muss mindestens 3 Zeichen lang sein", res.Error("username").Message)
+
+	// An explicit locale takes precedence over the Accept-Language header
+	r = newFormRequest(data)
+	r.Header.Set("Accept-Language", "de")
+	res = s.Validate(r.WithContext(WithLocale(r.Context(), "fr")))
+	assert.Equal(t, "Le champ name est obligatoire", res.Error("name").Message)
+
+	// Unknown locales fall back to the built-in messages
+	r = newFormRequest(data)
+	r.Header.Set("Accept-Language", "ja")
+	res = s.Validate(r)
+	assert.Equal(t, "Missing value for name field", res.Error("name").Message)
+}
+
+func TestCatalogsLoadFile(t *testing.T) {
+	cs := NewCatalogs()
+	assert.NoError(t, cs.LoadFile("nl", "testdata/catalog_nl.json"))
+	assert.NoError(t, cs.LoadFile("nl", "testdata/catalog_nl.yaml"))
+	assert.Error(t, cs.LoadFile("nl", "testdata/missing.json"))
+	assert.Error(t, cs.LoadFile("nl", "i18n.go"))
+	assert.Error(t, cs.Add("nl", Catalog{ERROR_CUSTOM: "{{.Label"}))
+
+	message, ok := cs.Translate("nl-BE", ERROR_MISSING_VALUE, MessageParams{Label: "naam"})
+	assert.True(t, ok)
+	assert.Equal(t, "Het veld naam is verplicht", message)
+	message, ok = cs.Translate("nl", ERROR_TOO_SHORT, MessageParams{Label: "naam", Min: "3"})
+	assert.True(t, ok)
+	assert.Equal(t, "Het veld naam moet minstens 3 tekens bevatten", message)
+	_, ok = cs.Translate("nl", ERROR_TOO_LONG, MessageParams{})
+	assert.False(t, ok)
+}
+
+func TestAcceptLanguages(t *testing.T) {
+	assert.Equal(t, []string{"fr-CH", "fr", "en", "de"}, acceptLanguages("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5"))
+	assert.Equal(t, []string{"en"}, acceptLanguages("da;q=0, en"))
+	assert.Empty(t, acceptLanguages(""))
+}
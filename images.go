@@ -0,0 +1,105 @@
+package form_validator
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	_ "golang.org/x/image/webp"
+)
+
+// ImageInfo holds the format & dimensions of an uploaded image
+type ImageInfo struct {
+	Format string
+	Width  int
+	Height int
+}
+
+// isFile reports whether the field's Type is an uploaded file type, or a slice of them
+func isFile(f *Field) bool {
+	switch strings.TrimPrefix(f.Type, "[]") {
+	case "file", "image":
+		return true
+	}
+	return false
+}
+
+// parseAspectRatio parses an aspect ratio such as "16:9" or "1.5" into its width & height
+func parseAspectRatio(ratio string) (float64, float64, error) {
+	w, h, found := strings.Cut(ratio, ":")
+	if !found {
+		h = "1"
+	}
+	width, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	height, err := strconv.ParseFloat(strings.TrimSpace(h), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("aspect ratio %q must be positive", ratio)
+	}
+	return width, height, nil
+}
+
+// checkAspectRatio panics if the field's AspectRatio isn't a valid aspect ratio
+func checkAspectRatio(f *Field) {
+	if f.AspectRatio == "" {
+		return
+	}
+	if _, _, err := parseAspectRatio(f.AspectRatio); err != nil {
+		panic(fmt.Sprintf("form_validator: invalid AspectRatio %q for %s field: %s", f.AspectRatio, f.Name, err))
+	}
+}
+
+// checkImage decodes only the header of an uploaded image with image.DecodeConfig, so the
+// dimensions are checked before any pixels are decoded. PNG, JPEG, GIF & WebP images are supported
+func checkImage(f *Field, r io.Reader) {
+	config, format, err := image.DecodeConfig(r)
+	if err != nil {
+		addError(f, ERROR_INCORRECT_TYPE)
+		return
+	}
+	f.imageInfo = &ImageInfo{Format: format, Width: config.Width, Height: config.Height}
+
+	if f.MaxPixels > 0 && int64(config.Width)*int64(config.Height) > f.MaxPixels {
+		addError(f, ERROR_TOO_MANY_PIXELS)
+	}
+	if bail(f) {
+		return
+	}
+	if config.Width < f.MinWidth || config.Height < f.MinHeight {
+		addError(f, ERROR_IMAGE_TOO_SMALL)
+	}
+	if bail(f) {
+		return
+	}
+	if (f.MaxWidth > 0 && config.Width > f.MaxWidth) || (f.MaxHeight > 0 && config.Height > f.MaxHeight) {
+		addError(f, ERROR_IMAGE_TOO_LARGE)
+	}
+	if bail(f) {
+		return
+	}
+	if f.AspectRatio != "" && !matchesAspectRatio(config.Width, config.Height, f.AspectRatio, f.AspectRatioTolerance) {
+		addError(f, ERROR_ASPECT_RATIO)
+	}
+}
+
+// matchesAspectRatio reports whether the dimensions are within the relative tolerance of the ratio
+func matchesAspectRatio(width, height int, ratio string, tolerance float64) bool {
+	w, h, err := parseAspectRatio(ratio)
+	if err != nil || height == 0 {
+		return false
+	}
+	want := w / h
+	got := float64(width) / float64(height)
+	return math.Abs(got-want)/want <= tolerance+1e-9
+}
@@ -0,0 +1,123 @@
+package form_validator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// webpPixel is a 1x1 lossless WebP image
+const webpPixel = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+func encodeImage(format string, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	var buf bytes.Buffer
+	switch format {
+	case "png":
+		png.Encode(&buf, img)
+	case "jpeg":
+		jpeg.Encode(&buf, img, nil)
+	case "gif":
+		gif.Encode(&buf, img, nil)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateImage(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:                 "banner",
+				Validate:             true,
+				Type:                 "image",
+				MinWidth:             160,
+				MaxWidth:             1920,
+				MinHeight:            90,
+				AspectRatio:          "16:9",
+				AspectRatioTolerance: 0.01,
+				MaxPixels:            1920 * 1080,
+			},
+		},
+	})
+	for _, format := range []string{"png", "jpeg", "gif"} {
+		res := s.Validate(newMultipartRequest(nil, testFile{"banner", "banner." + format, encodeImage(format, 320, 180)}))
+		assert.True(t, res.Valid(), res.Errors())
+		info, err := GetImageInfo("banner", res.Config())
+		assert.NoError(t, err)
+		assert.Equal(t, ImageInfo{Format: format, Width: 320, Height: 180}, info)
+		fh, err := GetFile("banner", res.Config())
+		assert.NoError(t, err)
+		assert.Equal(t, "banner."+format, fh.Filename)
+	}
+
+	webp, _ := base64.StdEncoding.DecodeString(webpPixel)
+	res := NewSchema(&Config{Fields: []Field{{Name: "icon", Type: "image"}}}).Validate(newMultipartRequest(nil, testFile{"icon", "icon.webp", webp}))
+	assert.True(t, res.Valid(), res.Errors())
+	info, err := GetImageInfo("icon", res.Config())
+	assert.NoError(t, err)
+	assert.Equal(t, ImageInfo{Format: "webp", Width: 1, Height: 1}, info)
+}
+
+func TestImageErrors(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:                 "banner",
+				Validate:             true,
+				Type:                 "image",
+				MinWidth:             160,
+				MaxWidth:             1920,
+				MinHeight:            90,
+				AspectRatio:          "16:9",
+				AspectRatioTolerance: 0.01,
+				MaxPixels:            1920 * 1080,
+			},
+		},
+	})
+
+	res := s.Validate(newMultipartRequest(nil, testFile{"banner", "banner.png", encodeImage("png", 16, 9)}))
+	assert.Equal(t, ERROR_IMAGE_TOO_SMALL, res.Error("banner").Type)
+	assert.Equal(t, "banner image must be at least 160 pixels wide and 90 pixels high", res.Error("banner").Message)
+
+	res = s.Validate(newMultipartRequest(nil, testFile{"banner", "banner.png", encodeImage("png", 2048, 1152)}))
+	assert.Equal(t, []string{ERROR_TOO_MANY_PIXELS, ERROR_IMAGE_TOO_LARGE}, errorTypes(res.Config().Fields[0].Errors))
+	assert.Equal(t, "banner image must be at most 1920 pixels wide", res.Config().Fields[0].Errors[1].Message)
+
+	res = s.Validate(newMultipartRequest(nil, testFile{"banner", "banner.png", encodeImage("png", 400, 300)}))
+	assert.Equal(t, ERROR_ASPECT_RATIO, res.Error("banner").Type)
+	assert.Equal(t, "banner image must have an aspect ratio of 16:9", res.Error("banner").Message)
+	assert.True(t, errors.Is(res.Err(), ErrAspectRatio))
+
+	// Within the aspect ratio tolerance
+	res = s.Validate(newMultipartRequest(nil, testFile{"banner", "banner.png", encodeImage("png", 1600, 901)}))
+	assert.True(t, res.Valid(), res.Errors())
+
+	res = s.Validate(newMultipartRequest(nil, testFile{"banner", "banner.png", []byte("not an image")}))
+	assert.Equal(t, ERROR_INCORRECT_TYPE, res.Error("banner").Type)
+	_, err := GetImageInfo("banner", res.Config())
+	assert.Error(t, err)
+}
+
+func TestInvalidAspectRatioPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "banner", Type: "image", AspectRatio: "wide"}}})
+	})
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "banner", Type: "image", AspectRatio: "16:0"}}})
+	})
+}
+
+func errorTypes(errs []Error) []string {
+	types := make([]string, len(errs))
+	for i, err := range errs {
+		types[i] = err.Type
+	}
+	return types
+}
@@ -0,0 +1,168 @@
+package form_validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxBodySize limits JSON request bodies if the Config's MaxBodySize is 0,
+// it matches the limit net/http applies to urlencoded forms
+const defaultMaxBodySize = 10 << 20
+
+// isJSON reports whether the request's Content-Type is application/json or a +json type
+func isJSON(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// ValidateJSON validates a JSON request body whatever its Content-Type. Like `ValidateForm`
+// the results are written back to `c`. `ValidateForm` & `Schema.Validate` validate JSON bodies
+// automatically if the Content-Type is application/json
+//
+// JSON values are converted from their literal text like form values, so a number must be written
+// in the syntax of the field's Type, e.g. an "int" field accepts 42 but not 42.0 or 1e3, & a string
+// holding a number such as "42" is accepted like the same form value
+//
+//	if ok := form_validator.ValidateJSON(r, &c); ok {
+//		// body is valid
+//	}
+func ValidateJSON(r *http.Request, c *Config) bool {
+	return NewSchema(c).validateJSON(r.Context(), r).apply(c)
+}
+
+// ValidateJSON validates a JSON request body whatever its Content-Type & returns a new `Result`
+func (s *Schema) ValidateJSON(r *http.Request) *Result {
+	return s.validateJSON(r.Context(), r)
+}
+
+// validateJSON validates the fields against the values of the JSON body. A body that can't be
+// decoded is rejected as a whole without validating the fields, its error is returned by the Result's `Err`
+func (s *Schema) validateJSON(ctx context.Context, r *http.Request) *Result {
+	values, containers, err := decodeJSON(r, s.maxBodySize)
+	if err == nil {
+		err = checkJSONContainers(s.fields, containers)
+	}
+	if err != nil {
+		log.Println(err.Error())
+		res := s.newResult(ctx, r.Header.Get("Accept-Language"))
+		res.config.bodyErr = err
+		return res
+	}
+	return s.validate(ctx, r, values, values)
+}
+
+// checkJSONContainers rejects an object submitted for a field read from the body, rather than
+// reporting the field as missing, & an array submitted for a field that holds a single value
+func checkJSONContainers(fields []Field, containers map[string]string) error {
+	for _, f := range fields {
+		if isFile(&f) || (f.Source != "" && f.Source != SOURCE_BODY) {
+			continue
+		}
+		switch containers[f.Name] {
+		case "object":
+			return fmt.Errorf("%w: expected a value for %s, got an object", ErrInvalidBody, f.Name)
+		case "array":
+			if !isMultiValue(&f) {
+				return fmt.Errorf("%w: expected a single value for %s, got an array", ErrInvalidBody, f.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// decodeJSON decodes a JSON object into values keyed by the dotted path of each value, e.g.
+// {"user": {"name": "Joe"}} is keyed by "user.name". Arrays of values are added under the same key
+// & numbers are kept in their literal form, so large integers aren't rounded. The paths of objects
+// & arrays are set to "object" or "array" in containers. Keys that occur more than once are rejected
+func decodeJSON(r *http.Request, maxBodySize int64) (url.Values, map[string]string, error) {
+	if maxBodySize == 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	dec.UseNumber()
+	values := url.Values{}
+	containers := map[string]string{}
+	if tok, err := dec.Token(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidBody, err)
+	} else if tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("%w: expected a JSON object", ErrInvalidBody)
+	}
+	if err := decodeJSONObject(dec, "", values, containers); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidBody, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, nil, fmt.Errorf("%w: unexpected data after the JSON object", ErrInvalidBody)
+	}
+	return values, containers, nil
+}
+
+// decodeJSONObject decodes the members of an object whose opening brace has been read
+func decodeJSONObject(dec *json.Decoder, prefix string, values url.Values, containers map[string]string) error {
+	keys := map[string]bool{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		// A dotted key such as "user.name" is the same field as a nested {"user": {"name": ...}}
+		if _, ok := values[path]; ok || keys[key] {
+			return fmt.Errorf("duplicate key %q", path)
+		}
+		keys[key] = true
+		if err := decodeJSONItem(dec, path, path, values, containers); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// decodeJSONItem decodes the next value of the decoder into values. Objects are decoded
+// under objectPath, which for the items of an array includes the item's index e.g. "users.0"
+func decodeJSONItem(dec *json.Decoder, path, objectPath string, values url.Values, containers map[string]string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			// An array holding objects is an object to the field of its path
+			containers[path], containers[objectPath] = "object", "object"
+			return decodeJSONObject(dec, objectPath, values, containers)
+		}
+		// Arrays of values are submitted like a checkbox group, once per value
+		if containers[path] == "" {
+			containers[path] = "array"
+		}
+		for i := 0; dec.More(); i++ {
+			if err := decodeJSONItem(dec, path, path+"."+strconv.Itoa(i), values, containers); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+		return err
+	case string:
+		values.Add(path, t)
+	case json.Number:
+		values.Add(path, t.String())
+	case bool:
+		values.Add(path, strconv.FormatBool(t))
+	case nil:
+		// null is the same as a missing value
+	}
+	return nil
+}
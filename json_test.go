@@ -0,0 +1,232 @@
+package form_validator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newJSONRequest(body string) *http.Request {
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return r
+}
+
+func TestValidateJSON(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "age",
+				Validate: true,
+				Type:     "uint8",
+				Max:      "120",
+			},
+			{
+				Name:     "id",
+				Validate: true,
+				Type:     "uint64",
+			},
+			{
+				Name:     "subscribe",
+				Validate: false,
+				Type:     "bool",
+			},
+			{
+				Name:     "address.city",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "tags",
+				Validate: false,
+				Type:     "[]string",
+			},
+			{
+				Name:     "users.1.email",
+				Validate: false,
+				Type:     "email",
+			},
+		},
+	}
+	body := `{
+		"name": "Joe",
+		"age": 42,
+		"id": 18446744073709551615,
+		"subscribe": true,
+		"address": {"city": "London"},
+		"tags": ["go", "forms"],
+		"users": [{"email": "a@email.com"}, {"email": "joe@email.com"}]
+	}`
+	assert.True(t, ValidateForm(newJSONRequest(body), &c))
+	assert.Equal(t, "Joe", c.Fields[0].Value)
+	assert.Equal(t, uint8(42), c.Fields[1].Value)
+	assert.Equal(t, uint64(18446744073709551615), c.Fields[2].Value)
+	// Unvalidated fields are converted too
+	assert.Equal(t, true, c.Fields[3].Value)
+	assert.Equal(t, "London", c.Fields[4].Value)
+	assert.Equal(t, []string{"go", "forms"}, c.Fields[5].Value)
+	email, err := GetEmail("users.1.email", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "joe@email.com", email.Address)
+
+	// Dotted keys are the same field as nested objects
+	body = `{"name": "Joe", "age": "42", "id": 1, "address.city": "London"}`
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+	assert.True(t, ValidateJSON(r, &c))
+	assert.Equal(t, "London", c.Fields[4].Value)
+}
+
+func TestJSONFormErrorsMatchForm(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "age",
+				Validate: true,
+				Type:     "uint8",
+				Max:      "120",
+			},
+			{
+				Name:     "id",
+				Validate: true,
+				Type:     "uint64",
+			},
+			{
+				Name:     "subscribe",
+				Validate: false,
+				Type:     "bool",
+			},
+			{
+				Name:     "address.city",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "tags",
+				Validate: false,
+				Type:     "[]string",
+			},
+			{
+				Name:     "users.1.email",
+				Validate: false,
+				Type:     "email",
+			},
+		},
+	})
+
+	data := url.Values{}
+	data.Set("age", "130")
+	data.Set("id", "-1")
+	data.Set("address.city", "")
+	data.Set("subscribe", "maybe")
+	formRes := s.Validate(newFormRequest(data))
+
+	jsonRes := s.Validate(newJSONRequest(`{"name": null, "age": 130, "id": -1, "address": {"city": ""}, "subscribe": "maybe"}`))
+	assert.False(t, jsonRes.Valid())
+	assert.Equal(t, formRes.Errors(), jsonRes.Errors())
+	assert.Len(t, jsonRes.Errors(), 5)
+	var ve *ValidationError
+	assert.True(t, errors.As(jsonRes.Err(), &ve))
+}
+
+func TestJSONNumbers(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:     "count",
+				Validate: true,
+				Type:     "int",
+			},
+			{
+				Name:     "ratio",
+				Validate: true,
+				Type:     "float64",
+			},
+		},
+	})
+	// Numbers are converted from their literal text like form values
+	for body, want := range map[string]string{
+		`{"count": 42, "ratio": 1e3}`:     "",
+		`{"count": "42", "ratio": "0.5"}`: "",
+		`{"count": 42.0, "ratio": 1}`:     ERROR_INCORRECT_TYPE,
+		`{"count": 1e3, "ratio": 1}`:      ERROR_INCORRECT_TYPE,
+	} {
+		res := s.Validate(newJSONRequest(body))
+		assert.Equal(t, want, res.Error("count").Type, body)
+		assert.Equal(t, "", res.Error("ratio").Type, body)
+	}
+	res := s.Validate(newJSONRequest(`{"count": "42", "ratio": 1e3}`))
+	assert.Equal(t, 42, res.Value("count"))
+	assert.Equal(t, 1000.0, res.Value("ratio"))
+}
+
+func TestInvalidJSONBody(t *testing.T) {
+	bodies := map[string]string{
+		"malformed":      `{"name": "Joe"`,
+		"not an object":  `["Joe"]`,
+		"duplicate key":  `{"name": "Joe", "name": "Jim"}`,
+		"duplicate path": `{"address": {"city": "London"}, "address.city": "Paris"}`,
+		"trailing data":  `{"name": "Joe"} {}`,
+		"empty":          ``,
+		"scalar array":   `{"name": "Joe", "age": [1, 2]}`,
+		"single item":    `{"name": ["Joe"]}`,
+		"empty array":    `{"address": {"city": []}}`,
+		"scalar object":  `{"address": {"city": {"name": "London"}}}`,
+		"array object":   `{"name": [{"first": "Joe"}]}`,
+	}
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "age",
+				Validate: true,
+				Type:     "uint8",
+			},
+			{
+				Name:     "address.city",
+				Validate: true,
+				Type:     "string",
+			},
+		},
+	})
+	for name, body := range bodies {
+		res := s.Validate(newJSONRequest(body))
+		assert.False(t, res.Valid(), name)
+		assert.True(t, errors.Is(res.Err(), ErrInvalidBody), name)
+		// The fields aren't validated against a body that was rejected
+		assert.Empty(t, res.Errors(), name)
+	}
+
+	s = NewSchema(&Config{
+		MaxBodySize: 16,
+		Fields: []Field{
+			{
+				Name: "name",
+				Type: "string",
+			},
+		},
+	})
+	res := s.Validate(newJSONRequest(`{"name": "Joe Gasewicz"}`))
+	assert.False(t, res.Valid())
+	var maxBytesErr *http.MaxBytesError
+	assert.True(t, errors.As(res.Err(), &maxBytesErr))
+	assert.True(t, s.Validate(newJSONRequest(`{"name": "Joe"}`)).Valid())
+}
@@ -0,0 +1,43 @@
+package form_validator
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"text/template"
+)
+
+// defaultMessages are the built-in English messages compiled from the bundled "en" catalog
+var defaultMessages = compileMessages(bundledCatalogs["en"])
+
+// compileMessages compiles the message templates keyed by error type. compileMessages panics
+// if a message isn't a valid template
+func compileMessages(messages map[string]string) map[string]*template.Template {
+	if len(messages) == 0 {
+		return nil
+	}
+	templates := make(map[string]*template.Template, len(messages))
+	for errType, message := range messages {
+		tmpl, err := template.New(errType).Parse(message)
+		if err != nil {
+			panic(fmt.Sprintf("form_validator: invalid %s message: %s", errType, err))
+		}
+		templates[errType] = tmpl
+	}
+	return templates
+}
+
+// executeMessage sets the field's error message from tmpl, it reports false if tmpl is nil
+// or fails to execute
+func executeMessage(f *Field, tmpl *template.Template) bool {
+	if tmpl == nil {
+		return false
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, messageParams(f)); err != nil {
+		log.Printf("Error executing %s message for %s field: %s", f.Error.Type, f.Name, err)
+		return false
+	}
+	f.Error.Message = buf.String()
+	return true
+}
@@ -0,0 +1,92 @@
+package form_validator
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageOverrides(t *testing.T) {
+	s := NewSchema(&Config{
+		Translator: NewCatalogs(),
+		Messages: map[string]string{
+			ERROR_MISSING_VALUE: "Please fill in {{.Label}}",
+			ERROR_TOO_SHORT:     "{{.Label}} is too short",
+		},
+		Fields: []Field{
+			{
+				Name:     "name",
+				Label:    "your name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:      "username",
+				Label:     "Username",
+				Validate:  true,
+				Type:      "string",
+				MinLength: 3,
+				Messages: map[string]string{
+					ERROR_TOO_SHORT: "{{.Label}} must be at least {{.Min}} characters, {{.Value}} is {{len .Value}}",
+				},
+			},
+			{
+				Name:     "age",
+				Validate: true,
+				Type:     "uint8",
+				Max:      "120",
+			},
+			{
+				Name:     "nickname",
+				Validate: true,
+				Type:     "string",
+				Validators: []ValidatorFunc{func(value interface{}, form *Result) *Error {
+					return &Error{Type: "ERROR_RESERVED"}
+				}},
+				Messages: map[string]string{
+					"ERROR_RESERVED": "{{.Value}} is reserved",
+				},
+			},
+		},
+	})
+	data := url.Values{}
+	data.Set("username", "jo")
+	data.Set("age", "130")
+	data.Set("nickname", "admin")
+
+	res := s.Validate(newFormRequest(data))
+	assert.Equal(t, "Please fill in your name", res.Error("name").Message)
+	assert.Equal(t, "Username must be at least 3 characters, jo is 2", res.Error("username").Message)
+	assert.Equal(t, "age field must be at most 120", res.Error("age").Message)
+	assert.Equal(t, "admin is reserved", res.Error("nickname").Message)
+
+	// The Config's Messages replace the English messages but not the translations,
+	// the field's own Messages take precedence over both
+	r := newFormRequest(data)
+	r.Header.Set("Accept-Language", "en")
+	res = s.Validate(r)
+	assert.Equal(t, "Please fill in your name", res.Error("name").Message)
+	assert.Equal(t, "age field must be at most 120", res.Error("age").Message)
+
+	r = newFormRequest(data)
+	r.Header.Set("Accept-Language", "de")
+	res = s.Validate(r)
+	assert.Equal(t, "Das Feld your name ist ein Pflichtfeld", res.Error("name").Message)
+	assert.Equal(t, "Das Feld age muss höchstens 120 sein", res.Error("age").Message)
+	assert.Equal(t, "Username must be at least 3 characters, jo is 2", res.Error("username").Message)
+}
+
+func TestInvalidMessageTemplatePanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSchema(&Config{
+			Fields: []Field{
+				{
+					Name:     "name",
+					Type:     "string",
+					Messages: map[string]string{ERROR_MISSING_VALUE: "{{.Label"},
+				},
+			},
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package form_validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// isMultiValue reports whether the field's Type is a slice type e.g. []string, []int
+func isMultiValue(f *Field) bool {
+	return strings.HasPrefix(f.Type, "[]")
+}
+
+// convertMultiValue converts & validates each of the submitted values individually as
+// the slice's element type. The field's Value is set to a slice of the converted values,
+// e.g. []int for a []int Type, leaving out the values that failed conversion. `ItemErrors`
+// holds the first error of each value. The errors of the values are added to the field's
+// errors after any error on the number of values
+func convertMultiValue(f *Field, values []string) {
+	f.Initials = nil
+	for _, v := range values {
+		if v != "" {
+			f.Initials = append(f.Initials, v)
+		}
+	}
+	f.Initial = strings.Join(f.Initials, ",")
+	resetErrors(f)
+	f.ItemErrors = make([]Error, len(f.Initials))
+	f.Value = nil
+
+	var items reflect.Value
+	var itemErrs []Error
+	for i, v := range f.Initials {
+		item := *f
+		item.Name = fmt.Sprintf("%s[%d]", f.Name, i)
+		item.Label = fmt.Sprintf("%s[%d]", f.label(), i)
+		item.Type = strings.TrimPrefix(f.Type, "[]")
+		item.Initial = v
+		item.Default = ""
+		item.Value = nil
+		resetErrors(&item)
+		if item.Type == "" {
+			item.Value = v
+		} else {
+			convertToType(&item)
+		}
+		// Values that failed conversion are left out of the slice
+		converted := item.Error.Type == ""
+		if converted {
+			checkConstraints(&item)
+		}
+		f.ItemErrors[i] = item.Error
+		itemErrs = append(itemErrs, item.Errors...)
+		if !converted || item.Value == nil {
+			continue
+		}
+		if !items.IsValid() {
+			items = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(item.Value)), 0, len(f.Initials))
+		}
+		items = reflect.Append(items, reflect.ValueOf(item.Value))
+	}
+	if items.IsValid() {
+		f.Value = items.Interface()
+	}
+
+	switch n := len(f.Initials); {
+	case n == 0 && f.Validate:
+		addError(f, ERROR_MISSING_VALUE)
+	case n > 0 && n < f.MinItems:
+		addError(f, ERROR_TOO_FEW_ITEMS)
+	case f.MaxItems > 0 && n > f.MaxItems:
+		addError(f, ERROR_TOO_MANY_ITEMS)
+	}
+	for _, err := range itemErrs {
+		if bail(f) {
+			return
+		}
+		appendError(f, err)
+	}
+}
@@ -0,0 +1,87 @@
+package form_validator
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiValues(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:      "tags",
+				Validate:  true,
+				Type:      "[]string",
+				MaxLength: 5,
+				MaxItems:  3,
+			},
+			{
+				Name:     "ids",
+				Validate: true,
+				Type:     "[]int",
+				Min:      "1",
+			},
+			{
+				Name:     "colours",
+				Validate: false,
+				Type:     "[]string",
+				MinItems: 2,
+				Choices:  []Choice{{Value: "red"}, {Value: "green"}, {Value: "blue"}},
+			},
+		},
+	})
+
+	data := url.Values{}
+	data["tags"] = []string{"go", "forms", ""}
+	data["ids"] = []string{"1", "2", "3"}
+	data["colours"] = []string{"red", "blue"}
+	res := s.Validate(newFormRequest(data))
+	assert.True(t, res.Valid())
+	assert.Equal(t, []string{"go", "forms"}, res.Value("tags"))
+	assert.Equal(t, []int{1, 2, 3}, res.Value("ids"))
+	ids, err := GetInts("ids", res.Config())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	colours, err := GetStrings("colours", res.Config())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"red", "blue"}, colours)
+	options := GetOptions("colours", res.Config())
+	assert.True(t, options[0].Selected)
+	assert.False(t, options[1].Selected)
+	assert.True(t, options[2].Selected)
+
+	// Each value is validated individually
+	data = url.Values{}
+	data["tags"] = []string{"go", "validator"}
+	data["ids"] = []string{"1", "zero", "0"}
+	data["colours"] = []string{"red", "pink"}
+	res = s.Validate(newFormRequest(data))
+	assert.False(t, res.Valid())
+	assert.Equal(t, ERROR_TOO_LONG, res.Error("tags").Type)
+	assert.Equal(t, "tags[1] field must be at most 5 characters", res.Error("tags").Message)
+	formErrs := res.Errors()
+	assert.Equal(t, "Expected a value of type int for ids[1] field", formErrs["ids"]["error"])
+	assert.Equal(t, "Expected a value of type int for ids[1] field", formErrs["ids"]["1"])
+	assert.Equal(t, "ids[2] field must be at least 1", formErrs["ids"]["2"])
+	assert.NotContains(t, formErrs["ids"], "0")
+	assert.Equal(t, ERROR_INVALID_CHOICE, res.Error("colours").Type)
+	// The value that failed conversion is left out, the one out of range is kept
+	assert.Equal(t, []int{1, 0}, res.Value("ids"))
+	data = url.Values{}
+	data["ids"] = []string{"1", "x"}
+	res = s.Validate(newFormRequest(data))
+	ids, _ = GetInts("ids", res.Config())
+	assert.Equal(t, []int{1}, ids)
+
+	// The number of values
+	data = url.Values{}
+	data["tags"] = []string{"a", "b", "c", "d"}
+	data["colours"] = []string{"red"}
+	res = s.Validate(newFormRequest(data))
+	assert.Equal(t, ERROR_TOO_MANY_ITEMS, res.Error("tags").Type)
+	assert.Equal(t, "tags field must have at most 3 values", res.Error("tags").Message)
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("ids").Type)
+	assert.Equal(t, ERROR_TOO_FEW_ITEMS, res.Error("colours").Type)
+}
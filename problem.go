@@ -0,0 +1,90 @@
+package form_validator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ValidationProblem is an RFC 7807 problem details object describing the form errors
+//
+//	{
+//		"type": "about:blank",
+//		"title": "Your request parameters didn't validate.",
+//		"status": 400,
+//		"invalid-params": [
+//			{"name": "age", "code": "incorrect_type", "message": "Expected a value of type uint8 for age field"}
+//		]
+//	}
+type ValidationProblem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// InvalidParam is a single error of a form field. The Code is the error type without
+// its "ERROR_" prefix in lowercase, e.g. "too_short" for an `ERROR_TOO_SHORT` error
+type InvalidParam struct {
+	Name    string `json:"name"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+const problemTitle = "Your request parameters didn't validate."
+
+// NewValidationProblem builds a `ValidationProblem` holding every error of the form's fields,
+// in the order of the fields. The Status is the Config's ProblemStatus, which defaults to 400.
+// A request body that couldn't be decoded is described by the Detail as its fields weren't validated
+//
+//	p := form_validator.NewValidationProblem(&c)
+//	p.Instance = r.URL.Path
+func NewValidationProblem(c *Config) *ValidationProblem {
+	p := &ValidationProblem{
+		Type:          "about:blank",
+		Title:         problemTitle,
+		Status:        c.ProblemStatus,
+		InvalidParams: []InvalidParam{},
+	}
+	if p.Status == 0 {
+		p.Status = http.StatusBadRequest
+	}
+	if errors.Is(c.bodyErr, ErrInvalidBody) {
+		p.Detail = c.bodyErr.Error()
+	}
+	for _, f := range c.Fields {
+		for _, err := range f.Errors {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{
+				Name:    f.Name,
+				Code:    errorCode(err.Type),
+				Message: err.Message,
+			})
+		}
+	}
+	return p
+}
+
+// Write writes the problem as an application/problem+json response with the problem's Status
+func (p *ValidationProblem) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
+
+// WriteProblem writes the form errors as an RFC 7807 application/problem+json response
+//
+//	if ok := form_validator.ValidateForm(r, &c); !ok {
+//		form_validator.WriteProblem(w, &c)
+//		return
+//	}
+func WriteProblem(w http.ResponseWriter, c *Config) error {
+	return NewValidationProblem(c).Write(w)
+}
+
+// errorCode returns the stable code of an error type
+func errorCode(errType string) string {
+	return strings.ToLower(strings.TrimPrefix(errType, "ERROR_"))
+}
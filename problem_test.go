@@ -0,0 +1,109 @@
+package form_validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteProblem(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:      "username",
+				Validate:  true,
+				Type:      "string",
+				MinLength: 5,
+				Pattern:   "[a-z]+",
+			},
+			{
+				Name:     "age",
+				Validate: false,
+				Type:     "uint8",
+			},
+		},
+	}
+	data := url.Values{}
+	data.Set("username", "J0")
+	data.Set("age", "42")
+	assert.False(t, ValidateForm(newFormRequest(data), &c))
+
+	w := httptest.NewRecorder()
+	assert.NoError(t, WriteProblem(w, &c))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var p ValidationProblem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, "about:blank", p.Type)
+	assert.Equal(t, http.StatusBadRequest, p.Status)
+	assert.Equal(t, []InvalidParam{
+		{Name: "name", Code: "missing_value", Message: "Missing value for name field"},
+		{Name: "username", Code: "too_short", Message: "username field must be at least 5 characters"},
+		{Name: "username", Code: "pattern_mismatch", Message: "username field is not in the correct format"},
+	}, p.InvalidParams)
+
+	var raw map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+	assert.Contains(t, raw, "invalid-params")
+}
+
+func TestWriteProblemStatus(t *testing.T) {
+	s := NewSchema(&Config{
+		ProblemStatus: http.StatusUnprocessableEntity,
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+		},
+	})
+	w := httptest.NewRecorder()
+	assert.NoError(t, s.Validate(newFormRequest(url.Values{})).WriteProblem(w))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// A valid form has no invalid params
+	data := url.Values{}
+	data.Set("name", "Joe")
+	p := NewValidationProblem(s.Validate(newFormRequest(data)).Config())
+	assert.Empty(t, p.InvalidParams)
+	assert.NotNil(t, p.InvalidParams)
+}
+
+func TestWriteProblemInvalidBody(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+		},
+	}
+	w := httptest.NewRecorder()
+	assert.NoError(t, NewSchema(&c).Validate(newJSONRequest(`{"name": "Jo`)).WriteProblem(w))
+	var p ValidationProblem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, "form_validator: invalid request body: unexpected EOF", p.Detail)
+	// The fields of a body that was never decoded aren't reported as missing
+	assert.Empty(t, p.InvalidParams)
+
+	assert.False(t, ValidateForm(newJSONRequest(`{"name": ["Jo", "e"]}`), &c))
+	p = *NewValidationProblem(&c)
+	assert.Equal(t, "form_validator: invalid request body: expected a single value for name, got an array", p.Detail)
+	assert.Empty(t, p.InvalidParams)
+
+	// The Detail is cleared once the Config validates a decodable body
+	assert.True(t, ValidateForm(newJSONRequest(`{"name": "Joe"}`), &c))
+	assert.Empty(t, NewValidationProblem(&c).Detail)
+}
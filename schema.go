@@ -0,0 +1,280 @@
+package form_validator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Schema is a compiled, read-only copy of a `Config`. Unlike a `Config` passed to
+// `ValidateForm`, a Schema is never mutated so it can be declared once at startup
+// & shared between handlers serving concurrent requests
+//
+//	var signUp = form_validator.NewSchema(&form_validator.Config{
+//		Fields: []form_validator.Field{
+//			{
+//				Name:     "email",
+//				Validate: true,
+//				Type:     "string",
+//			},
+//		},
+//	})
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		res := signUp.Validate(r)
+//		if res.Valid() {
+//			email, _ := form_validator.GetString("email", res.Config())
+//		}
+//	}
+type Schema struct {
+	maxMemory      int64
+	maxBodySize    int64
+	tempDir        string
+	maxConcurrency int
+	asyncTimeout   time.Duration
+	translator     Translator
+	problemStatus  int
+	bind           bool
+	fields         []Field
+}
+
+// Result holds the values & errors of a single validated request
+type Result struct {
+	config    Config
+	form      *multipart.Form
+	tempFiles map[string][]string
+}
+
+// NewSchema compiles a `Config` into a `Schema`. Any request state already held by
+// the config's fields (`Value`, `Initial`, `Error` etc.) is discarded.
+// NewSchema panics if a field's constraints are misconfigured, e.g. a Max that isn't a number,
+// a Pattern that isn't a valid regular expression, a message that isn't a valid template,
+// an unknown Source or an AspectRatio that isn't a ratio
+func NewSchema(c *Config) *Schema {
+	s := &Schema{
+		maxMemory:      c.MaxMemory,
+		maxBodySize:    c.MaxBodySize,
+		tempDir:        c.TempDir,
+		maxConcurrency: c.MaxConcurrency,
+		asyncTimeout:   c.AsyncTimeout,
+		translator:     c.Translator,
+		problemStatus:  c.ProblemStatus,
+		bind:           c.bind,
+		fields:         make([]Field, len(c.Fields)),
+	}
+	configMessages := compileMessages(c.Messages)
+	for i, f := range c.Fields {
+		f.Value = nil
+		f.Initial = ""
+		resetErrors(&f)
+		f.Initials = nil
+		f.ItemErrors = nil
+		f.localizer = nil
+		f.messages = compileMessages(f.Messages)
+		f.configMessages = configMessages
+		f.imageInfo = nil
+		f.matchesLabel = f.Matches
+		for _, matched := range c.Fields {
+			if f.Matches != "" && matched.Name == f.Matches {
+				f.matchesLabel = matched.label()
+			}
+		}
+		checkSource(&f)
+		checkAspectRatio(&f)
+		checkBounds(&f)
+		if f.Pattern != "" {
+			compilePattern(f.Pattern)
+		}
+		s.fields[i] = f
+	}
+	return s
+}
+
+// Validate validates the request's form, multipart form or JSON body & returns a new `Result`.
+// Async validators are run with the request's context
+//
+//	res := schema.Validate(r)
+//	if res.Valid() {
+//		// form is valid
+//	} else {
+//		// form is invalid
+//	}
+func (s *Schema) Validate(r *http.Request) *Result {
+	return s.ValidateContext(r.Context(), r)
+}
+
+// ValidateContext validates the request's form, multipart form or JSON body like `Validate`,
+// passing ctx to the fields' async validators
+//
+//	res := schema.ValidateContext(ctx, r)
+func (s *Schema) ValidateContext(ctx context.Context, r *http.Request) *Result {
+	checkTempDir(s.tempDir)
+	if isJSON(r) {
+		return s.validateJSON(ctx, r)
+	}
+	if err := r.ParseMultipartForm(s.maxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		log.Println(err.Error())
+	}
+	return s.validate(ctx, r, r.Form, r.PostForm)
+}
+
+// validate runs the validation against the values & files of a request which has already been parsed.
+// form holds the values of fields without a Source & body the values of the request's body.
+// The temporary files of an invalid multipart form are removed
+func (s *Schema) validate(ctx context.Context, r *http.Request, form, body url.Values) *Result {
+	var files map[string][]*multipart.FileHeader
+	if r.MultipartForm != nil {
+		files = r.MultipartForm.File
+	}
+	values := sourceValues(r, s.fields, form, body)
+	res := s.validateValues(ctx, r.Header.Get("Accept-Language"), values, files)
+	res.form = r.MultipartForm
+	if !res.Valid() {
+		res.Close()
+	}
+	return res
+}
+
+// ValidateValues validates values & files that have been parsed elsewhere, such as a query string,
+// CLI input or a stored draft & returns a new `Result`. The error messages are in the locale set
+// on ctx with `WithLocale`
+//
+//	values, _ := url.ParseQuery("name=Joe&age=42")
+//	res := schema.ValidateValues(ctx, values, nil)
+func (s *Schema) ValidateValues(ctx context.Context, values url.Values, files map[string][]*multipart.FileHeader) *Result {
+	return s.validateValues(ctx, "", values, files)
+}
+
+// validateValues runs the validation, acceptLanguage is the Accept-Language header of the request if any
+func (s *Schema) validateValues(ctx context.Context, acceptLanguage string, values url.Values, files map[string][]*multipart.FileHeader) *Result {
+	res := s.newResult(ctx, acceptLanguage)
+	validate(values, files, &res.config)
+	validateAsync(ctx, &res.config)
+	return res
+}
+
+// newResult returns a Result holding a copy of the schema's fields before any validation
+func (s *Schema) newResult(ctx context.Context, acceptLanguage string) *Result {
+	res := &Result{
+		config: Config{
+			MaxMemory:      s.maxMemory,
+			MaxBodySize:    s.maxBodySize,
+			TempDir:        s.tempDir,
+			MaxConcurrency: s.maxConcurrency,
+			AsyncTimeout:   s.asyncTimeout,
+			Translator:     s.translator,
+			ProblemStatus:  s.problemStatus,
+			bind:           s.bind,
+			Fields:         make([]Field, len(s.fields)),
+		},
+	}
+	copy(res.config.Fields, s.fields)
+	if l := newLocalizer(ctx, acceptLanguage, s.translator); l != nil {
+		for i := range res.config.Fields {
+			res.config.Fields[i].localizer = l
+		}
+	}
+	return res
+}
+
+// apply copies the result's field state back onto the caller's config
+func (res *Result) apply(c *Config) bool {
+	copy(c.Fields, res.config.Fields)
+	c.bodyErr = res.config.bodyErr
+	return res.Valid()
+}
+
+// Valid reports whether every field passed validation
+func (res *Result) Valid() bool {
+	return res.config.bodyErr == nil && isFormValid(&res.config)
+}
+
+// Err returns a `*ValidationError` holding the form errors, or nil if the form is valid.
+// A JSON body that couldn't be decoded returns an error wrapping `ErrInvalidBody`, as does
+// a streamed multipart form that couldn't be read
+//
+//	if err := schema.Validate(r).Err(); err != nil {
+//		return err
+//	}
+func (res *Result) Err() error {
+	if res.config.bodyErr != nil {
+		return res.config.bodyErr
+	}
+	return validationError(&res.config)
+}
+
+// Close removes the temporary files the request's multipart form was parsed into, as well as the files
+// `ValidateStream` spooled to the TempDir. The files of an invalid form are removed by the validation,
+// so Close only needs to be called once a valid form's files are no longer needed.
+// `GetFile` headers of files that weren't held in memory can't be opened once the Result is closed
+//
+//	res := schema.Validate(r)
+//	defer res.Close()
+func (res *Result) Close() error {
+	var errs []error
+	if res.form != nil {
+		errs = append(errs, res.form.RemoveAll())
+		res.form = nil
+	}
+	for _, paths := range res.tempFiles {
+		for _, path := range paths {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	res.tempFiles = nil
+	return errors.Join(errs...)
+}
+
+// TempFiles gets the paths of the temporary files `ValidateStream` spooled a field's files to,
+// in the order they were uploaded
+func (res *Result) TempFiles(name string) []string {
+	return res.tempFiles[name]
+}
+
+// Config returns the per request config holding the validated fields. It can be passed
+// to any of the `Get<TYPE>` functions as well as `GetFormError` & `GetFormErrors`
+//
+//	name, _ := form_validator.GetString("name", res.Config())
+func (res *Result) Config() *Config {
+	return &res.config
+}
+
+// Value gets the converted value of a field
+func (res *Result) Value(name string) interface{} {
+	return getFormValue(name, &res.config)
+}
+
+// Label gets the label of a single field
+func (res *Result) Label(name string) string {
+	return GetLabel(name, &res.config)
+}
+
+// Labels gets the labels of every field
+func (res *Result) Labels() map[string]string {
+	return GetLabels(&res.config)
+}
+
+// WriteProblem writes the form errors as an RFC 7807 application/problem+json response
+func (res *Result) WriteProblem(w http.ResponseWriter) error {
+	return WriteProblem(w, &res.config)
+}
+
+// Error gets the error of a single field
+func (res *Result) Error(name string) Error {
+	return GetFormError(name, &res.config)
+}
+
+// Errors gets all the form errors as `FormErrors`
+func (res *Result) Errors() FormErrors {
+	var formErrs = FormErrors{}
+	GetFormErrors(&res.config, &formErrs)
+	return formErrs
+}
@@ -0,0 +1,239 @@
+package form_validator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFormRequest(data url.Values) *http.Request {
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(data.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestSchemaValidate(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "age",
+				Validate: true,
+				Type:     "uint8",
+			},
+		},
+	}
+	s := NewSchema(&c)
+
+	data := url.Values{}
+	data.Set("name", "Joe")
+	data.Set("age", "42")
+	res := s.Validate(newFormRequest(data))
+	assert.True(t, res.Valid())
+	assert.Equal(t, "Joe", res.Value("name"))
+	age, err := GetUint8("age", res.Config())
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(42), age)
+
+	data = url.Values{}
+	data.Set("age", "42")
+	res = s.Validate(newFormRequest(data))
+	assert.False(t, res.Valid())
+	assert.Nil(t, res.Value("name"))
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("name").Type)
+	assert.Equal(t, "Missing value for name field", res.Errors()["name"]["error"])
+
+	// The declaring config is never written to
+	assert.Nil(t, c.Fields[0].Value)
+	assert.Equal(t, "", c.Fields[0].Error.Type)
+}
+
+func TestValidateFormDoesNotLeakValues(t *testing.T) {
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: false,
+				Type:     "string",
+			},
+		},
+	}
+
+	data := url.Values{}
+	data.Set("name", "Joe")
+	assert.True(t, ValidateForm(newFormRequest(data), &c))
+	assert.Equal(t, "Joe", c.Fields[0].Value)
+
+	assert.True(t, ValidateForm(newFormRequest(url.Values{}), &c))
+	assert.Nil(t, c.Fields[0].Value)
+	assert.Equal(t, "", c.Fields[0].Initial)
+}
+
+// TestSchemaConcurrentValidate shares a single schema between hundreds of goroutines,
+// run it with `go test -race` to detect data races
+func TestSchemaConcurrentValidate(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+			{
+				Name:     "id",
+				Validate: true,
+				Type:     "int",
+			},
+			{
+				Name:     "confirm_id",
+				Validate: true,
+				Type:     "int",
+				Matches:  "id",
+			},
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 500; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data := url.Values{}
+			data.Set("name", fmt.Sprintf("user-%d", i))
+			data.Set("id", fmt.Sprint(i))
+			// Every third request is invalid
+			if i%3 == 0 {
+				data.Set("confirm_id", "-1")
+			} else {
+				data.Set("confirm_id", fmt.Sprint(i))
+			}
+			res := s.Validate(newFormRequest(data))
+			assert.Equal(t, i%3 != 0, res.Valid())
+			assert.Equal(t, fmt.Sprintf("user-%d", i), res.Value("name"))
+			assert.Equal(t, i, res.Value("id"))
+		}(i)
+	}
+	wg.Wait()
+}
+
+// openFiles counts the open file descriptors of the process, -1 if they can't be listed
+func openFiles() int {
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	return len(fds)
+}
+
+func dirNames(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestResultCloseRemovesTempFiles(t *testing.T) {
+	// ParseMultipartForm writes files larger than MaxMemory to os.TempDir(), a TempDir can only be
+	// set for ValidateStream
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	fds := openFiles()
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:              "avatar",
+				Validate:          true,
+				Type:              "file",
+				MaxFileSize:       1024,
+				AllowedExtensions: []string{"png", ".JPG"},
+				AllowedMIMETypes:  []string{"image/png", "image/jpeg"},
+			},
+		},
+	})
+
+	res := s.Validate(newMultipartRequest(nil, testFile{"avatar", "me.png", pngHeader}))
+	assert.True(t, res.Valid())
+	assert.NotEmpty(t, dirNames(t, dir))
+	fh, _ := GetFile("avatar", res.Config())
+	f, err := fh.Open()
+	if assert.NoError(t, err) {
+		f.Close()
+	}
+	assert.NoError(t, res.Close())
+	assert.Empty(t, dirNames(t, dir))
+	assert.NoError(t, res.Close())
+
+	// The files of an invalid form are removed without calling Close
+	res = s.Validate(newMultipartRequest(nil, testFile{"avatar", "me.gif", pngHeader}))
+	assert.False(t, res.Valid())
+	assert.Empty(t, dirNames(t, dir))
+
+	c := Config{Fields: []Field{{Name: "avatar", Validate: true, Type: "file", AllowedMIMETypes: []string{"image/gif"}}}}
+	assert.False(t, ValidateMultiPartForm(newMultipartRequest(nil, testFile{"avatar", "me.png", pngHeader}), &c))
+	assert.Empty(t, dirNames(t, dir))
+	assert.Equal(t, fds, openFiles())
+}
+
+func TestTempDirOnlySupportedByStreams(t *testing.T) {
+	c := Config{
+		TempDir: t.TempDir(),
+		Fields:  []Field{{Name: "avatar", Validate: true, Type: "file"}},
+	}
+	assert.Panics(t, func() {
+		NewSchema(&c).Validate(newMultipartRequest(nil, testFile{"avatar", "me.png", pngHeader}))
+	})
+	assert.Panics(t, func() {
+		ValidateMultiPartForm(newMultipartRequest(nil, testFile{"avatar", "me.png", pngHeader}), &c)
+	})
+	assert.Panics(t, func() {
+		Validate(newMultipartRequest(nil, testFile{"avatar", "me.png", pngHeader}), &c)
+	})
+
+	r, _ := newStreamRequest(testFile{"avatar", "me.png", pngHeader})
+	res := NewSchema(&c).ValidateStream(r, nil)
+	assert.True(t, res.Valid(), res.Errors())
+	assert.NoError(t, res.Close())
+}
+
+func TestValidateStreamTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := NewSchema(&Config{
+		TempDir: dir,
+		Fields:  []Field{{Name: "avatar", Validate: true, Type: "file", MaxFileSize: 1024}},
+	})
+	fds := openFiles()
+
+	r, _ := newStreamRequest(testFile{"avatar", "me.png", pngHeader})
+	res := s.ValidateStream(r, nil)
+	assert.True(t, res.Valid(), res.Errors())
+	paths := res.TempFiles("avatar")
+	if assert.Len(t, paths, 1) {
+		assert.Equal(t, dir, filepath.Dir(paths[0]))
+		content, _ := os.ReadFile(paths[0])
+		assert.Equal(t, pngHeader, content)
+	}
+	assert.NoError(t, res.Close())
+	assert.Empty(t, dirNames(t, dir))
+
+	r, _ = newStreamRequest(testFile{"avatar", "me.png", make([]byte, 2048)})
+	res = s.ValidateStream(r, nil)
+	assert.Equal(t, ERROR_FILE_TOO_LARGE, res.Error("avatar").Type)
+	assert.Empty(t, res.TempFiles("avatar"))
+	assert.Empty(t, dirNames(t, dir))
+	assert.Equal(t, fds, openFiles())
+}
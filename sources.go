@@ -0,0 +1,65 @@
+package form_validator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// The sources a field's value can be read from. A field without a Source is read from
+// the form, which merges the query string & the body
+const (
+	SOURCE_QUERY  = "query"
+	SOURCE_BODY   = "body"
+	SOURCE_HEADER = "header"
+	SOURCE_COOKIE = "cookie"
+	SOURCE_PATH   = "path"
+)
+
+// checkSource panics if the field's Source isn't one of the sources
+func checkSource(f *Field) {
+	switch f.Source {
+	case "", SOURCE_QUERY, SOURCE_BODY, SOURCE_HEADER, SOURCE_COOKIE, SOURCE_PATH:
+	default:
+		panic(fmt.Sprintf("form_validator: unknown Source %q for %s field", f.Source, f.Name))
+	}
+}
+
+// sourceValues returns the values the fields are validated against. Fields without a Source
+// read the form values, the other fields read only their Source, so a value in the query string
+// can't satisfy a field read from the body. body holds the values of the request's body
+func sourceValues(r *http.Request, fields []Field, form, body url.Values) url.Values {
+	values := make(url.Values, len(form))
+	for key, v := range form {
+		values[key] = v
+	}
+	for _, f := range fields {
+		if f.Source == "" {
+			continue
+		}
+		values.Del(f.Name)
+		switch f.Source {
+		case SOURCE_QUERY:
+			if v, ok := r.URL.Query()[f.Name]; ok {
+				values[f.Name] = v
+			}
+		case SOURCE_BODY:
+			if v, ok := body[f.Name]; ok {
+				values[f.Name] = v
+			}
+		case SOURCE_HEADER:
+			if v := r.Header.Values(f.Name); len(v) > 0 {
+				values[f.Name] = v
+			}
+		case SOURCE_COOKIE:
+			if cookie, err := r.Cookie(f.Name); err == nil {
+				values.Set(f.Name, cookie.Value)
+			}
+		case SOURCE_PATH:
+			if v := r.PathValue(f.Name); v != "" {
+				values.Set(f.Name, v)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,133 @@
+package form_validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldSources(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:     "page",
+				Validate: true,
+				Type:     "uint",
+				Source:   SOURCE_QUERY,
+			},
+			{
+				Name:     "amount",
+				Validate: true,
+				Type:     "uint64",
+				Source:   SOURCE_BODY,
+			},
+			{
+				Name:     "X-Request-Id",
+				Validate: true,
+				Type:     "uuid",
+				Source:   SOURCE_HEADER,
+			},
+			{
+				Name:     "session",
+				Validate: true,
+				Type:     "string",
+				Source:   SOURCE_COOKIE,
+			},
+			{
+				Name:     "id",
+				Validate: true,
+				Type:     "int",
+				Source:   SOURCE_PATH,
+			},
+			{
+				Name:     "note",
+				Validate: false,
+				Type:     "string",
+			},
+		},
+	})
+	var res *Result
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		res = s.Validate(r)
+	})
+
+	data := url.Values{}
+	data.Set("amount", "100")
+	r := httptest.NewRequest("POST", "/accounts/42?page=2&note=query", strings.NewReader(data.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Request-Id", "F47AC10B-58CC-4372-A567-0E02B2C3D479")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.True(t, res.Valid(), res.Errors())
+	assert.Equal(t, uint(2), res.Value("page"))
+	assert.Equal(t, uint64(100), res.Value("amount"))
+	assert.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", res.Value("X-Request-Id"))
+	assert.Equal(t, "abc", res.Value("session"))
+	assert.Equal(t, 42, res.Value("id"))
+	// Fields without a Source read the form, which includes the query string
+	assert.Equal(t, "query", res.Value("note"))
+}
+
+func TestBodyFieldNotSatisfiedByQuery(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:     "page",
+				Validate: true,
+				Type:     "uint",
+				Source:   SOURCE_QUERY,
+			},
+			{
+				Name:     "amount",
+				Validate: true,
+				Type:     "uint64",
+				Source:   SOURCE_BODY,
+			},
+			{
+				Name:     "X-Request-Id",
+				Validate: true,
+				Type:     "uuid",
+				Source:   SOURCE_HEADER,
+			},
+			{
+				Name:     "session",
+				Validate: true,
+				Type:     "string",
+				Source:   SOURCE_COOKIE,
+			},
+			{
+				Name:     "id",
+				Validate: true,
+				Type:     "int",
+				Source:   SOURCE_PATH,
+			},
+		},
+	})
+	r := httptest.NewRequest("POST", "/accounts/42?amount=100&page=2", strings.NewReader(""))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res := s.Validate(r)
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("amount").Type)
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("X-Request-Id").Type)
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("session").Type)
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("id").Type)
+	assert.Equal(t, "", res.Error("page").Type)
+
+	// A body field is read from a JSON body but never from the query string
+	r = httptest.NewRequest("POST", "/accounts/42?amount=5", strings.NewReader(`{"amount": 100, "page": 3}`))
+	r.Header.Set("Content-Type", "application/json")
+	res = s.Validate(r)
+	assert.Equal(t, uint64(100), res.Value("amount"))
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("page").Type)
+}
+
+func TestUnknownSourcePanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSchema(&Config{Fields: []Field{{Name: "name", Source: "env"}}})
+	})
+}
@@ -0,0 +1,250 @@
+package form_validator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// FileSink returns the writer an uploaded file is streamed to by `ValidateStream`. fh holds the
+// file's name & MIME header, its Size is set once the file has been copied. A writer that is also
+// an io.Closer is closed after the file has been copied
+//
+//	sink := func(field string, fh *multipart.FileHeader) (io.Writer, error) {
+//		return os.Create(filepath.Join(uploadDir, filepath.Base(fh.Filename)))
+//	}
+type FileSink func(field string, fh *multipart.FileHeader) (io.Writer, error)
+
+// ValidateMultiPartStream validates a multipart form as it's read like `Schema.ValidateStream`.
+// Like `ValidateForm` the results are written back to `c`
+//
+//	if ok := form_validator.ValidateMultiPartStream(r, &c, sink); ok {
+//		// form is valid
+//	}
+func ValidateMultiPartStream(r *http.Request, c *Config, sink FileSink) bool {
+	return NewSchema(c).ValidateStream(r, sink).apply(c)
+}
+
+// ValidateStream validates a multipart form part by part as it's read with r.MultipartReader,
+// rather than parsing the whole form before any rule runs like `Validate`. A file is checked against
+// its field's extensions, content types & image dimensions from the first bytes of the file before
+// it's copied to the writer returned by sink, so uploads are never buffered in memory or temporary files.
+// If sink is nil the files are spooled to temporary files in the Config's TempDir, see `Result.TempFiles`.
+// The temporary files are removed if the form is invalid, otherwise by `Result.Close`
+//
+// Reading stops at the first file that fails validation or exceeds its MaxFileSize, & at a file
+// uploaded to a field that isn't a file Type, which fails with `ErrInvalidBody`. The fields after
+// it aren't validated & any file already written to sink should be discarded if the Result isn't valid
+//
+//	res := schema.ValidateStream(r, sink)
+func (s *Schema) ValidateStream(r *http.Request, sink FileSink) *Result {
+	res := s.newResult(r.Context(), r.Header.Get("Accept-Language"))
+	if sink == nil {
+		sink = res.tempFileSink(s.tempDir)
+	}
+	s.validateStream(r, res, sink)
+	if !res.Valid() {
+		res.Close()
+	}
+	return res
+}
+
+// validateStream streams the parts of the form & validates the fields against the form values
+func (s *Schema) validateStream(r *http.Request, res *Result, sink FileSink) {
+	ctx := r.Context()
+	body, err := s.streamParts(r, res.config.Fields, sink)
+	if err != nil {
+		log.Println(err.Error())
+		res.config.bodyErr = err
+		return
+	}
+	if !isFormValid(&res.config) {
+		return
+	}
+	// The form merges the body & the query string like r.Form
+	form := url.Values{}
+	for key, v := range body {
+		form[key] = append(form[key], v...)
+	}
+	for key, v := range r.URL.Query() {
+		form[key] = append(form[key], v...)
+	}
+	validate(sourceValues(r, s.fields, form, body), nil, &res.config)
+	validateAsync(ctx, &res.config)
+}
+
+// checkTempDir panics if a TempDir is set for a validation that parses the form with
+// r.ParseMultipartForm, which would silently write the files to os.TempDir() instead
+func checkTempDir(tempDir string) {
+	if tempDir != "" {
+		panic("form_validator: TempDir is only supported by ValidateStream & ValidateMultiPartStream")
+	}
+}
+
+// tempFileSink returns a sink creating a temporary file in dir for each file, which is recorded
+// by the Result so it can be removed by `Close`
+func (res *Result) tempFileSink(dir string) FileSink {
+	return func(field string, fh *multipart.FileHeader) (io.Writer, error) {
+		file, err := os.CreateTemp(dir, "form_validator-")
+		if err != nil {
+			return nil, err
+		}
+		if res.tempFiles == nil {
+			res.tempFiles = map[string][]string{}
+		}
+		res.tempFiles[field] = append(res.tempFiles[field], file.Name())
+		return file, nil
+	}
+}
+
+// streamParts reads the parts of the multipart form, returning the values of the parts that aren't files.
+// The values are limited to the schema's MaxBodySize in total
+func (s *Schema) streamParts(r *http.Request, fields []Field, sink FileSink) (url.Values, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBody, err)
+	}
+	remaining := s.maxBodySize
+	if remaining == 0 {
+		remaining = defaultMaxBodySize
+	}
+	values := url.Values{}
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBody, err)
+		}
+		name := p.FormName()
+		if name == "" {
+			continue
+		}
+		if p.FileName() == "" {
+			b, err := io.ReadAll(io.LimitReader(p, remaining+1))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidBody, err)
+			}
+			if remaining -= int64(len(b)); remaining < 0 {
+				return nil, fmt.Errorf("%w: form values too large", ErrInvalidBody)
+			}
+			values.Add(name, string(b))
+			continue
+		}
+		var f *Field
+		for i := range fields {
+			if fields[i].Name == name && isFile(&fields[i]) {
+				f = &fields[i]
+			}
+		}
+		if f == nil {
+			return nil, fmt.Errorf("%w: unexpected file for %s", ErrInvalidBody, name)
+		}
+		fh := &multipart.FileHeader{Filename: p.FileName(), Header: p.Header}
+		if isMultiValue(f) {
+			err = streamFiles(f, fh, p, sink)
+		} else if f.Value == nil {
+			// Only the first file of a "file" Type is read
+			err = streamFile(f, name, fh, p, sink, -1)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if f.Error.Type != "" {
+			return values, nil
+		}
+	}
+	for i, f := range fields {
+		if fhs, ok := f.Value.([]*multipart.FileHeader); ok {
+			fields[i].Initial = strings.Join(f.Initials, ",")
+			if len(fhs) < f.MinFiles {
+				addError(&fields[i], ERROR_TOO_FEW_FILES)
+			}
+		}
+	}
+	return values, nil
+}
+
+// streamFiles streams the next file of a "[]file" or "[]image" Type, checking it as the slice's
+// element type. The number & total size of the files are checked as they're read
+func streamFiles(f *Field, fh *multipart.FileHeader, p io.Reader, sink FileSink) error {
+	fhs, _ := f.Value.([]*multipart.FileHeader)
+	if f.MaxFiles > 0 && len(fhs) == f.MaxFiles {
+		addError(f, ERROR_TOO_MANY_FILES)
+		return nil
+	}
+	var total int64
+	for _, prev := range fhs {
+		total += prev.Size
+	}
+	maxSize := int64(-1)
+	if f.MaxTotalFileSize > 0 {
+		maxSize = f.MaxTotalFileSize - total
+	}
+	item := fileItem(f, len(fhs))
+	if err := streamFile(&item, f.Name, fh, p, sink, maxSize); err != nil {
+		return err
+	}
+	f.Value = append(fhs, fh)
+	f.Initials = append(f.Initials, fh.Filename)
+	f.ItemErrors = append(f.ItemErrors, item.Error)
+	for _, err := range item.Errors {
+		appendError(f, err)
+	}
+	if f.MaxTotalFileSize > 0 && total+fh.Size > f.MaxTotalFileSize && f.Error.Type == "" {
+		addError(f, ERROR_FILES_TOO_LARGE)
+	}
+	return nil
+}
+
+// streamFile checks the file's extension & content before copying it to the writer returned by sink.
+// The copy stops once the file exceeds the field's MaxFileSize or maxSize, unless maxSize is negative
+func streamFile(f *Field, name string, fh *multipart.FileHeader, p io.Reader, sink FileSink, maxSize int64) error {
+	f.Initial = fh.Filename
+	f.Value = fh
+	if len(f.AllowedExtensions) > 0 && !allowedExtension(fh.Filename, f.AllowedExtensions) {
+		addError(f, ERROR_FILE_EXTENSION)
+		return nil
+	}
+	br := bufio.NewReaderSize(p, sniffLen)
+	var content io.Reader = br
+	if checksContent(f) {
+		content = checkContent(f, br)
+		if f.Error.Type != "" {
+			return nil
+		}
+	}
+	w, err := sink(name, fh)
+	if err != nil {
+		return fmt.Errorf("form_validator: %s file sink: %w", name, err)
+	}
+	limit := int64(-1)
+	if f.MaxFileSize > 0 {
+		limit = f.MaxFileSize
+	}
+	if maxSize >= 0 && (limit < 0 || maxSize < limit) {
+		limit = maxSize
+	}
+	if limit >= 0 {
+		content = io.LimitReader(content, limit+1)
+	}
+	fh.Size, err = io.Copy(w, content)
+	if closer, ok := w.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("form_validator: %s file: %w", name, err)
+	}
+	if f.MaxFileSize > 0 && fh.Size > f.MaxFileSize {
+		addError(f, ERROR_FILE_TOO_LARGE)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package form_validator
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingReader counts the bytes read from the request body
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func (c *countingReader) Close() error { return nil }
+
+// newStreamRequest writes the parts in order, a part without a filename is a form value
+func newStreamRequest(parts ...testFile) (*http.Request, *countingReader) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for _, p := range parts {
+		if p.filename == "" {
+			mw.WriteField(p.field, string(p.content))
+			continue
+		}
+		fw, _ := mw.CreateFormFile(p.field, p.filename)
+		fw.Write(p.content)
+	}
+	mw.Close()
+	r := httptest.NewRequest("POST", "/upload", nil)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	counter := &countingReader{r: body}
+	r.Body = counter
+	return r, counter
+}
+
+// bufferSink collects the streamed files by filename
+type bufferSink map[string]*bytes.Buffer
+
+func (b bufferSink) sink(field string, fh *multipart.FileHeader) (io.Writer, error) {
+	b[fh.Filename] = &bytes.Buffer{}
+	return b[fh.Filename], nil
+}
+
+func TestValidateStream(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{Name: "title", Validate: true, Type: "string"},
+			{Name: "banner", Validate: true, Type: "image", MinWidth: 160, AllowedMIMETypes: []string{"image/*"}},
+		},
+	})
+	banner := encodeImage("png", 320, 180)
+	r, _ := newStreamRequest(testFile{"title", "", []byte("Hello")}, testFile{"banner", "banner.png", banner})
+	files := bufferSink{}
+	res := s.ValidateStream(r, files.sink)
+	assert.True(t, res.Valid(), res.Errors())
+	assert.Equal(t, "Hello", res.Value("title"))
+	fh, err := GetFile("banner", res.Config())
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(len(banner)), fh.Size)
+	}
+	info, _ := GetImageInfo("banner", res.Config())
+	assert.Equal(t, 320, info.Width)
+	// The bytes read to check the image are written to the sink
+	assert.Equal(t, banner, files["banner.png"].Bytes())
+
+	r, _ = newStreamRequest(testFile{"banner", "banner.png", banner})
+	res = s.ValidateStream(r, bufferSink{}.sink)
+	assert.Equal(t, ERROR_MISSING_VALUE, res.Error("title").Type)
+}
+
+func TestValidateStreamAbortsEarly(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{Name: "avatar", Validate: true, Type: "file", MaxFileSize: 1024, AllowedExtensions: []string{"png"}, AllowedMIMETypes: []string{"image/png"}},
+			{Name: "title", Validate: true, Type: "string"},
+		},
+	})
+	large := append(pngHeader, make([]byte, 1<<20)...)
+
+	r, body := newStreamRequest(testFile{"avatar", "me.png", large}, testFile{"title", "", []byte("Hello")})
+	files := bufferSink{}
+	res := s.ValidateStream(r, files.sink)
+	assert.Equal(t, ERROR_FILE_TOO_LARGE, res.Error("avatar").Type)
+	assert.Equal(t, "", res.Error("title").Type)
+	assert.Equal(t, 1025, files["me.png"].Len())
+	assert.Less(t, body.n, 1<<19)
+
+	r, body = newStreamRequest(testFile{"avatar", "me.gif", large})
+	files = bufferSink{}
+	res = s.ValidateStream(r, files.sink)
+	assert.Equal(t, ERROR_FILE_EXTENSION, res.Error("avatar").Type)
+	assert.Empty(t, files)
+	assert.Less(t, body.n, 1<<19)
+
+	r, _ = newStreamRequest(testFile{"avatar", "me.png", []byte("<html><script>alert(1)</script></html>")})
+	files = bufferSink{}
+	res = s.ValidateStream(r, files.sink)
+	assert.Equal(t, ERROR_FILE_MIME_TYPE, res.Error("avatar").Type)
+	assert.Empty(t, files)
+
+	r, body = newStreamRequest(testFile{"title", "me.png", large})
+	files = bufferSink{}
+	res = s.ValidateStream(r, files.sink)
+	assert.False(t, res.Valid())
+	assert.True(t, errors.Is(res.Err(), ErrInvalidBody))
+	assert.Empty(t, files)
+	assert.Less(t, body.n, 1<<19)
+}
+
+func TestValidateStreamFiles(t *testing.T) {
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{Name: "attachments", Type: "[]file", MinFiles: 2, MaxFiles: 2, MaxTotalFileSize: 1024},
+		},
+	})
+	a := testFile{"attachments", "a.png", pngHeader}
+	b := testFile{"attachments", "b.png", pngHeader}
+
+	r, _ := newStreamRequest(a, b)
+	res := s.ValidateStream(r, bufferSink{}.sink)
+	assert.True(t, res.Valid(), res.Errors())
+	assert.Len(t, GetFiles("attachments", res.Config()), 2)
+
+	r, _ = newStreamRequest(a)
+	res = s.ValidateStream(r, bufferSink{}.sink)
+	assert.Equal(t, ERROR_TOO_FEW_FILES, res.Error("attachments").Type)
+
+	files := bufferSink{}
+	r, _ = newStreamRequest(a, b, testFile{"attachments", "c.png", pngHeader})
+	res = s.ValidateStream(r, files.sink)
+	assert.Equal(t, ERROR_TOO_MANY_FILES, res.Error("attachments").Type)
+	assert.Len(t, files, 2)
+
+	r, _ = newStreamRequest(a, testFile{"attachments", "b.png", make([]byte, 1024)})
+	res = s.ValidateStream(r, bufferSink{}.sink)
+	assert.Equal(t, ERROR_FILES_TOO_LARGE, res.Error("attachments").Type)
+}
+
+func TestValidateStreamSinkError(t *testing.T) {
+	s := NewSchema(&Config{Fields: []Field{{Name: "avatar", Type: "file"}}})
+	errSink := errors.New("disk full")
+	r, _ := newStreamRequest(testFile{"avatar", "me.png", pngHeader})
+	res := s.ValidateStream(r, func(string, *multipart.FileHeader) (io.Writer, error) {
+		return nil, errSink
+	})
+	assert.False(t, res.Valid())
+	assert.True(t, errors.Is(res.Err(), errSink))
+}
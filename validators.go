@@ -0,0 +1,36 @@
+package form_validator
+
+// ValidatorFunc is a custom validation function. It receives the field's converted
+// value & the form, so other fields can be accessed e.g. `form.Value("start_date")`.
+// Validators only run for fields whose value was submitted & converted without errors,
+// whether or not the field is set to Validate.
+// Return nil if the value is valid, otherwise an *Error. The error's Type defaults to
+// `ERROR_CUSTOM` & the Message to the built-in message of the Type
+//
+//	notAdmin := func(value interface{}, form *form_validator.Result) *form_validator.Error {
+//		if value == "admin" {
+//			return &form_validator.Error{Type: "ERROR_RESERVED", Message: "This username is reserved"}
+//		}
+//		return nil
+//	}
+type ValidatorFunc func(value interface{}, form *Result) *Error
+
+// runValidators runs the field's validators in order
+func runValidators(f *Field, form *Result) {
+	for _, validator := range f.Validators {
+		if bail(f) {
+			return
+		}
+		if err := validator(f.Value, form); err != nil {
+			addValidatorError(f, *err)
+		}
+	}
+}
+
+// addValidatorError adds an error returned by a validator, the Type defaults to `ERROR_CUSTOM`
+func addValidatorError(f *Field, err Error) {
+	if err.Type == "" {
+		err.Type = ERROR_CUSTOM
+	}
+	appendError(f, err)
+}
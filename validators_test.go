@@ -0,0 +1,122 @@
+package form_validator
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidators(t *testing.T) {
+	notReserved := func(value interface{}, form *Result) *Error {
+		if value == "admin" {
+			return &Error{Type: "ERROR_RESERVED", Message: "This username is reserved"}
+		}
+		return nil
+	}
+	afterStart := func(value interface{}, form *Result) *Error {
+		if value.(int) <= form.Value("start").(int) {
+			return &Error{}
+		}
+		return nil
+	}
+	tooShort := func(value interface{}, form *Result) *Error {
+		return &Error{Type: ERROR_TOO_SHORT}
+	}
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:       "username",
+				Validate:   true,
+				Type:       "string",
+				Validators: []ValidatorFunc{notReserved},
+			},
+			{
+				Name:     "start",
+				Validate: true,
+				Type:     "int",
+			},
+			{
+				Name:       "end",
+				Validate:   true,
+				Type:       "int",
+				Validators: []ValidatorFunc{afterStart},
+			},
+			{
+				Name:       "code",
+				Type:       "string",
+				MinLength:  4,
+				Validators: []ValidatorFunc{tooShort},
+			},
+		},
+	})
+
+	data := url.Values{}
+	data.Set("username", "joe")
+	data.Set("start", "1")
+	data.Set("end", "2")
+	res := s.Validate(newFormRequest(data))
+	assert.True(t, res.Valid())
+
+	data.Set("username", "admin")
+	data.Set("end", "1")
+	data.Set("code", "abcd")
+	res = s.Validate(newFormRequest(data))
+	assert.False(t, res.Valid())
+	assert.Equal(t, Error{Type: "ERROR_RESERVED", Message: "This username is reserved"}, res.Error("username"))
+	assert.Equal(t, Error{Type: ERROR_CUSTOM, Message: "Invalid value for end field"}, res.Error("end"))
+	assert.Equal(t, "code field must be at least 4 characters", res.Errors()["code"]["error"])
+	assert.Equal(t, "This username is reserved", res.Errors()["username"]["error"])
+
+	// Validators don't run for values that failed the built-in validation
+	data.Set("end", "one")
+	res = s.Validate(newFormRequest(data))
+	assert.Equal(t, ERROR_INCORRECT_TYPE, res.Error("end").Type)
+}
+
+func TestValidatorsOfUnvalidatedFields(t *testing.T) {
+	var values []interface{}
+	even := func(value interface{}, form *Result) *Error {
+		values = append(values, value)
+		if value.(int)%2 != 0 {
+			return &Error{}
+		}
+		return nil
+	}
+	evenAsync := func(ctx context.Context, value interface{}, form *Result) *Error {
+		return even(value, form)
+	}
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:       "count",
+				Type:       "int",
+				Validators: []ValidatorFunc{even},
+			},
+			{
+				Name:            "total",
+				Type:            "int",
+				AsyncValidators: []AsyncValidatorFunc{evenAsync},
+			},
+		},
+	})
+
+	// The validators receive the converted value
+	data := url.Values{}
+	data.Set("count", "5")
+	data.Set("total", "4")
+	res := s.Validate(newFormRequest(data))
+	assert.Equal(t, ERROR_CUSTOM, res.Error("count").Type)
+	assert.Equal(t, "", res.Error("total").Type)
+	assert.ElementsMatch(t, []interface{}{5, 4}, values)
+
+	// Empty & unconvertible values aren't validated
+	values = nil
+	data.Set("count", "")
+	data.Set("total", "four")
+	res = s.Validate(newFormRequest(data))
+	assert.Equal(t, "", res.Error("count").Type)
+	assert.Equal(t, ERROR_INCORRECT_TYPE, res.Error("total").Type)
+	assert.Empty(t, values)
+}
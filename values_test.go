@@ -0,0 +1,91 @@
+package form_validator
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateValuesQueryOnly(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?page=3&name=Joe", nil)
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "page",
+				Validate: true,
+				Type:     "uint",
+			},
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+		},
+	}
+	assert.True(t, ValidateValues(r.URL.Query(), nil, &c))
+	assert.Equal(t, uint(3), c.Fields[0].Value)
+	assert.Equal(t, "Joe", c.Fields[1].Value)
+
+	values, err := url.ParseQuery("page=two")
+	assert.NoError(t, err)
+	assert.False(t, ValidateValues(values, nil, &c))
+	assert.Equal(t, ERROR_INCORRECT_TYPE, c.Fields[0].Error.Type)
+	assert.Equal(t, ERROR_MISSING_VALUE, c.Fields[1].Error.Type)
+}
+
+func TestValidateValuesBodyOnly(t *testing.T) {
+	data := url.Values{}
+	data.Set("name", "Joe")
+	r := httptest.NewRequest("POST", "/test?name=Jim&page=3", strings.NewReader(data.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.NoError(t, r.ParseForm())
+
+	// The page in the query string isn't part of the body
+	c := Config{
+		Fields: []Field{
+			{
+				Name:     "page",
+				Validate: true,
+				Type:     "uint",
+			},
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+		},
+	}
+	assert.False(t, ValidateValues(r.PostForm, nil, &c))
+	assert.Equal(t, ERROR_MISSING_VALUE, c.Fields[0].Error.Type)
+	assert.Equal(t, "Joe", c.Fields[1].Value)
+}
+
+func TestValidateValuesMerged(t *testing.T) {
+	data := url.Values{}
+	data.Set("name", "Joe")
+	r := httptest.NewRequest("POST", "/test?page=3", strings.NewReader(data.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.NoError(t, r.ParseForm())
+
+	s := NewSchema(&Config{
+		Fields: []Field{
+			{
+				Name:     "page",
+				Validate: true,
+				Type:     "uint",
+			},
+			{
+				Name:     "name",
+				Validate: true,
+				Type:     "string",
+			},
+		},
+	})
+	res := s.ValidateValues(r.Context(), r.Form, nil)
+	assert.True(t, res.Valid())
+	assert.Equal(t, uint(3), res.Value("page"))
+	assert.Equal(t, "Joe", res.Value("name"))
+}